@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// adaptiveGridFlag replaces the fixed DefaultGridConfigs grid with one
+// whose spacing snaps to the nearest power of ten below the camera's
+// current world-per-pixel scale, crossfading between adjacent decades
+// as the camera zooms in or out instead of popping between them - a
+// fixed 1-unit grid turns into a gray blur zoomed far out and is too
+// sparse zoomed in close, which is the usual complaint CAD viewers
+// solve this way.
+var adaptiveGridFlag = flag.Bool("adaptive-grid", false, "use a grid whose spacing adapts to zoom instead of the fixed default grid")
+
+// adaptiveGridTargetPixels is the screen-space spacing, in pixels, the
+// adaptive grid tries to keep its minor lines near; targetSpacing is
+// picked so that, at the camera's current distance and fov, one grid
+// cell projects to roughly this many pixels.
+const adaptiveGridTargetPixels = 60
+
+// adaptiveGridLines is how many lines each decade's Grid extends to
+// either side of the origin, matching DefaultGridConfigs' fine grid.
+const adaptiveGridLines = 20
+
+// adaptiveGridState holds the two adjacent-decade Grids currently built
+// (fine and the next coarser one) plus the exponent they were built
+// for, so updateAdaptiveGrid only rebuilds the GL buffers when the
+// camera crosses into a new decade rather than every frame.
+var adaptiveGridState struct {
+	exponent   int
+	built      bool
+	fineGrid   *Grid
+	coarseGrid *Grid
+}
+
+// adaptiveGridConfig returns the GridConfig every decade uses, just at
+// a different Spacing - same look as DefaultGridConfigs' fine grid.
+func adaptiveGridConfig(spacing float32) GridConfig {
+	cfg := DefaultGridConfigs[0]
+	cfg.Spacing = spacing
+	cfg.Lines = adaptiveGridLines
+	return cfg
+}
+
+// updateAdaptiveGrid recomputes the target grid spacing from camera's
+// distance to the origin and fov, rebuilding the fine/coarse decade
+// Grids if the target spacing crossed into a new decade, and sets their
+// FadeAlpha so the two crossfade smoothly as the camera zooms.
+//
+// distance-to-origin is a simplification: a true CAD viewer would use
+// distance to the ground plane under the cursor or view center, but the
+// scenes this renders are small enough that the origin is always close
+// to what's in view.
+func updateAdaptiveGrid(camera *Camera) {
+	distance := camera.Position.Len()
+	if distance < 0.01 {
+		distance = 0.01
+	}
+
+	worldPerPixel := 2 * distance * tan32(mgl32.DegToRad(camera.Fov)/2) / float32(max(fbHeight, 1))
+	targetSpacing := worldPerPixel * adaptiveGridTargetPixels
+	if targetSpacing < 1e-6 {
+		targetSpacing = 1e-6
+	}
+
+	exponent := int(math.Floor(math.Log10(float64(targetSpacing))))
+	base := float32(math.Pow(10, float64(exponent)))
+	// t is how far targetSpacing sits between base and the next decade
+	// up (base*10), in [0, 1); drives the fine->coarse crossfade.
+	t := float32(math.Log10(float64(targetSpacing/base)))
+
+	if !adaptiveGridState.built || exponent != adaptiveGridState.exponent {
+		if adaptiveGridState.built {
+			adaptiveGridState.fineGrid.Delete()
+			adaptiveGridState.coarseGrid.Delete()
+		}
+		adaptiveGridState.fineGrid = NewGridFromConfig(adaptiveGridConfig(base))
+		adaptiveGridState.coarseGrid = NewGridFromConfig(adaptiveGridConfig(base * 10))
+		adaptiveGridState.exponent = exponent
+		adaptiveGridState.built = true
+	}
+
+	adaptiveGridState.fineGrid.FadeAlpha = 1 - t
+	adaptiveGridState.coarseGrid.FadeAlpha = t
+}
+
+// drawAdaptiveGrid updates and draws the adaptive grid's two current
+// decade levels, following the camera the same way the fixed grid's
+// infiniteGrid toggle does.
+func drawAdaptiveGrid(camera *Camera) {
+	updateAdaptiveGrid(camera)
+	for _, g := range []*Grid{adaptiveGridState.fineGrid, adaptiveGridState.coarseGrid} {
+		g.UpdateFollow(camera.Position, infiniteGrid)
+		g.Draw()
+	}
+}
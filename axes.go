@@ -0,0 +1,240 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// defaultAxisThickness is the screen-space line width, in pixels, used
+// when a caller doesn't override it via NewAxesWithThickness.
+const defaultAxisThickness = float32(2.0)
+
+// Axes renders the X (red), Y (green) and Z (blue) world axes through the
+// origin, each of the given length and with its own selectable
+// thickness. Thickness is expanded into a camera-facing quad by a
+// geometry shader (see thickLineGeometryShader) rather than drawn with
+// gl.LineWidth: core-profile drivers commonly clamp GL_ALIASED_LINE_WIDTH
+// to 1px (see setLineWidth/initLineWidth), which would make the intended
+// thickness invisible for exactly the lines - the axes - where it
+// matters most for reading the scene at a glance.
+type Axes struct {
+	program           uint32
+	vao, vbo          uint32
+	modelUniform      int32
+	viewportUniform   int32
+	thicknessUniform  int32
+
+	// thickness[i] is axis i's line width in screen-space pixels, in X,
+	// Y, Z order.
+	thickness [3]float32
+
+	// Visible[i] gates axis i's draw call, in X, Y, Z order, so a caller
+	// can hide individual axes (e.g. to inspect a plane without its
+	// normal axis in the way) without rebuilding the buffer. See
+	// SceneObjects, which drawSceneCore uses to keep this in sync with
+	// the 1/2/3 keys.
+	Visible [3]bool
+}
+
+// NewAxes builds the three axis lines, each running from -length to
+// +length along its world axis, all drawn at the default thickness.
+func NewAxes(length float32) *Axes {
+	return NewAxesWithThickness(length, [3]float32{defaultAxisThickness, defaultAxisThickness, defaultAxisThickness})
+}
+
+// NewAxesWithThickness is like NewAxes but lets each axis use its own
+// screen-space pixel width, e.g. to make the vertical axis stand out.
+// Gradient shading is off; use NewAxesWithStyle for that.
+func NewAxesWithThickness(length float32, thickness [3]float32) *Axes {
+	return NewAxesWithStyle(length, thickness, false)
+}
+
+// axisOriginDarkness is the RGB fraction of an axis' full color used at
+// the origin end of each half-line when gradient is true, fading up to
+// full color at the tip - baked into the existing per-vertex color
+// attribute rather than a new uniform, since the vertex/geometry shader
+// pipeline already interpolates vColor/gColor across the line.
+const axisOriginDarkness = float32(0.15)
+
+// axisNegativeDimFactor further dims the entire negative half of each
+// axis when gradient is true, so the positive and negative directions
+// read as distinct at a glance instead of mirroring each other.
+const axisNegativeDimFactor = float32(0.45)
+
+// axisColors are the X, Y, Z axes' full-brightness colors - red, green,
+// blue - shared by the flat and gradient vertex layouts.
+var axisColors = [3]mgl32.Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// NewAxesWithStyle is like NewAxesWithThickness but can additionally
+// split each axis into a positive and negative half with distinct
+// per-vertex colors: dark at the origin fading to full brightness at
+// each tip, with the negative half dimmed overall by
+// axisNegativeDimFactor so direction is readable at a glance. Without
+// gradient, behavior matches NewAxesWithThickness exactly - a single
+// flat color per axis.
+func NewAxesWithStyle(length float32, thickness [3]float32, gradient bool) *Axes {
+	vertices := axisVertices(length, gradient)
+
+	program, err := newGeometryProgram(thickLineVertexShader, thickLineGeometryShader, thickLineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	vao, vbo := newInterleavedBuffer(vertices, 3, 4)
+
+	return &Axes{
+		program:          program,
+		vao:              vao,
+		vbo:              vbo,
+		modelUniform:     gl.GetUniformLocation(program, gl.Str("model\x00")),
+		viewportUniform:  gl.GetUniformLocation(program, gl.Str("viewportSize\x00")),
+		thicknessUniform: gl.GetUniformLocation(program, gl.Str("lineWidthPixels\x00")),
+		thickness:        thickness,
+		Visible:          [3]bool{true, true, true},
+	}
+}
+
+// axisVertices builds each axis as two 2-vertex line segments - origin
+// to +length, then origin to -length - so Draw can keep issuing one
+// draw call per axis (4 vertices = 2 LINES primitives) while the
+// negative half carries its own color. Segment order per axis is
+// positive then negative, at offset i*4 in the returned buffer.
+func axisVertices(length float32, gradient bool) []float32 {
+	dirs := [3]mgl32.Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+	var verts []float32
+	appendSegment := func(tip mgl32.Vec3, color mgl32.Vec3, dim float32) {
+		originColor, tipColor := color.Mul(dim), color.Mul(dim)
+		if gradient {
+			originColor = originColor.Mul(axisOriginDarkness)
+		}
+		verts = append(verts,
+			0, 0, 0, originColor.X(), originColor.Y(), originColor.Z(), 1,
+			tip.X(), tip.Y(), tip.Z(), tipColor.X(), tipColor.Y(), tipColor.Z(), 1,
+		)
+	}
+
+	for i, dir := range dirs {
+		negativeDim := float32(1)
+		if gradient {
+			negativeDim = axisNegativeDimFactor
+		}
+		appendSegment(dir.Mul(length), axisColors[i], 1)
+		appendSegment(dir.Mul(-length), axisColors[i], negativeDim)
+	}
+	return verts
+}
+
+// Draw renders the axis lines, issuing one draw call per axis so each
+// can use its own pixel thickness. view/projection come from the shared
+// Matrices UBO (see updateMatricesUBO); viewport size comes from the
+// live fbWidth/fbHeight globals, since the geometry shader needs pixel
+// dimensions to expand a line into a constant-width quad.
+func (a *Axes) Draw() {
+	identity := mgl32.Ident4()
+	gl.UseProgram(a.program)
+	gl.UniformMatrix4fv(a.modelUniform, 1, false, &identity[0])
+	gl.Uniform2f(a.viewportUniform, float32(fbWidth), float32(fbHeight))
+
+	blending := beginLineBlend()
+	gl.BindVertexArray(a.vao)
+	for i, w := range a.thickness {
+		if !a.Visible[i] {
+			continue
+		}
+		gl.Uniform1f(a.thicknessUniform, w*lineWidthScale)
+		gl.DrawArrays(gl.LINES, int32(i*4), 4)
+	}
+	gl.BindVertexArray(0)
+	endLineBlend(blending)
+}
+
+// Delete frees the axes' GL resources.
+func (a *Axes) Delete() {
+	gl.DeleteVertexArrays(1, &a.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &a.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(a.program)
+	trackDelete("program")
+}
+
+// thickLineVertexShader passes the line's clip-space position and color
+// straight through to thickLineGeometryShader, which does the actual
+// width expansion.
+const thickLineVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec4 aColor;
+
+uniform mat4 model;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+out vec4 vColor;
+
+void main() {
+	gl_Position = projection * view * model * vec4(aPos, 1.0);
+	vColor = aColor;
+}
+` + "\x00"
+
+// thickLineGeometryShader expands each 2-vertex line into a 4-vertex
+// triangle strip (a camera-facing quad) of a constant width in screen
+// pixels, regardless of distance or gl.LineWidth driver support: it
+// works in normalized device coordinates, offsetting each endpoint
+// perpendicular to the line's on-screen direction by half the requested
+// pixel width converted to NDC via viewportSize.
+const thickLineGeometryShader = `
+#version 460 core
+layout (lines) in;
+layout (triangle_strip, max_vertices = 4) out;
+
+in vec4 vColor[];
+out vec4 gColor;
+
+uniform vec2 viewportSize;
+uniform float lineWidthPixels;
+
+void main() {
+	vec4 p0 = gl_in[0].gl_Position;
+	vec4 p1 = gl_in[1].gl_Position;
+
+	vec2 ndc0 = p0.xy / p0.w;
+	vec2 ndc1 = p1.xy / p1.w;
+
+	vec2 dir = normalize((ndc1 - ndc0) * viewportSize);
+	vec2 normalDir = vec2(-dir.y, dir.x);
+	vec2 offset = normalDir * (lineWidthPixels / viewportSize);
+
+	gl_Position = vec4((ndc0 + offset) * p0.w, p0.z, p0.w);
+	gColor = vColor[0];
+	EmitVertex();
+
+	gl_Position = vec4((ndc0 - offset) * p0.w, p0.z, p0.w);
+	gColor = vColor[0];
+	EmitVertex();
+
+	gl_Position = vec4((ndc1 + offset) * p1.w, p1.z, p1.w);
+	gColor = vColor[1];
+	EmitVertex();
+
+	gl_Position = vec4((ndc1 - offset) * p1.w, p1.z, p1.w);
+	gColor = vColor[1];
+	EmitVertex();
+
+	EndPrimitive();
+}
+` + "\x00"
+
+const thickLineFragmentShader = `
+#version 460 core
+in vec4 gColor;
+out vec4 FragColor;
+
+void main() {
+	FragColor = gColor;
+}
+` + "\x00"
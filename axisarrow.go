@@ -0,0 +1,122 @@
+package main
+
+import (
+	"math"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// arrowConeSegments controls how round each axis arrowhead looks; higher
+// is smoother at the cost of more triangles.
+const arrowConeSegments = 12
+
+// axisArrowLength is the distance from the origin to the base of each
+// cone, matching the 5.0 length Axes is built with so the arrows sit
+// right at the tip of their axis line. arrowConeLength/Radius are kept
+// proportional to it so the arrows read as direction markers rather
+// than overwhelming the line they cap.
+const (
+	axisArrowLength = 5.0
+	arrowConeLength = axisArrowLength * 0.08
+	arrowConeRadius = axisArrowLength * 0.03
+)
+
+// createAxisArrows builds a single cone, apex pointing out along +Z, as
+// a triangle fan: the apex plus a closed ring of base vertices. The same
+// geometry is drawn three times by AxisArrows.Draw, once per axis, with
+// a different model transform and color each time.
+func createAxisArrows() (vao, vbo uint32, vertexCount int32) {
+	vertices := []float32{0, 0, arrowConeLength}
+	for i := 0; i <= arrowConeSegments; i++ {
+		theta := 2 * math.Pi * float64(i) / float64(arrowConeSegments)
+		vertices = append(vertices,
+			arrowConeRadius*float32(math.Cos(theta)),
+			arrowConeRadius*float32(math.Sin(theta)),
+			0)
+	}
+
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+	gl.VertexAttribPointerWithOffset(0, 3, gl.FLOAT, false, 3*4, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.BindVertexArray(0)
+
+	return vao, vbo, int32(len(vertices) / 3)
+}
+
+// axisArrowTransforms rotate the cone's local +Z axis to point along
+// axis i (X, Y, Z order, matching Axes) and translate it out to the tip
+// of that axis line.
+var axisArrowTransforms = [3]mgl32.Mat4{
+	mgl32.Translate3D(axisArrowLength, 0, 0).Mul4(mgl32.HomogRotate3D(mgl32.DegToRad(90), mgl32.Vec3{0, 1, 0})),
+	mgl32.Translate3D(0, axisArrowLength, 0).Mul4(mgl32.HomogRotate3D(mgl32.DegToRad(-90), mgl32.Vec3{1, 0, 0})),
+	mgl32.Translate3D(0, 0, axisArrowLength),
+}
+
+// axisArrowColors match Axes: X red, Y green, Z blue.
+var axisArrowColors = [3]mgl32.Vec3{
+	{1, 0, 0},
+	{0, 1, 0},
+	{0, 0, 1},
+}
+
+// AxisArrows draws a small cone at the tip of each world axis, pointing
+// outward, so it's clear at a glance which way is positive.
+type AxisArrows struct {
+	program      uint32
+	vao, vbo     uint32
+	vertexCount  int32
+	modelUniform int32
+	colorUniform int32
+}
+
+// NewAxisArrows builds the shared cone geometry and compiles its shader.
+// It reuses WireCube's flat-color shader, which already takes exactly
+// the model/color uniforms a solid cone needs beyond the shared
+// Matrices UBO.
+func NewAxisArrows() *AxisArrows {
+	program, err := newProgram(outlineVertexShader, outlineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	vao, vbo, vertexCount := createAxisArrows()
+
+	return &AxisArrows{
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		vertexCount:  vertexCount,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("color\x00")),
+	}
+}
+
+// Draw renders one cone per axis, colored to match Axes. view/projection
+// come from the shared Matrices UBO (see updateMatricesUBO).
+func (a *AxisArrows) Draw() {
+	gl.UseProgram(a.program)
+	gl.BindVertexArray(a.vao)
+	for i, model := range axisArrowTransforms {
+		gl.UniformMatrix4fv(a.modelUniform, 1, false, &model[0])
+		c := axisArrowColors[i]
+		gl.Uniform4f(a.colorUniform, c.X(), c.Y(), c.Z(), 1)
+		gl.DrawArrays(gl.TRIANGLE_FAN, 0, a.vertexCount)
+	}
+	gl.BindVertexArray(0)
+}
+
+// Delete frees the arrows' GL resources.
+func (a *AxisArrows) Delete() {
+	gl.DeleteVertexArrays(1, &a.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &a.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(a.program)
+	trackDelete("program")
+}
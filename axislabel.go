@@ -0,0 +1,45 @@
+package main
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// axisLabelDistance places each glyph at the tip of its axis line, to
+// match the 5.0 length NewAxesWithStyle is called with in Init.
+const axisLabelDistance = 5.0
+
+// axisLabels holds the three billboarded "X"/"Y"/"Z" glyphs drawn by
+// drawAxisLabels, tinted to match the axis they sit on.
+var axisLabels []*TextLabel
+
+// newAxisLabels builds the X/Y/Z glyphs, billboarded and tinted red,
+// green and blue respectively to match Axes.
+func newAxisLabels() []*TextLabel {
+	x := NewTextLabel("X", mgl32.Vec3{axisLabelDistance, 0, 0})
+	x.Billboard = true
+	x.Color = mgl32.Vec3{1, 0, 0}
+
+	y := NewTextLabel("Y", mgl32.Vec3{0, axisLabelDistance, 0})
+	y.Billboard = true
+	y.Color = mgl32.Vec3{0, 1, 0}
+
+	z := NewTextLabel("Z", mgl32.Vec3{0, 0, axisLabelDistance})
+	z.Billboard = true
+	z.Color = mgl32.Vec3{0, 0, 1}
+
+	return []*TextLabel{x, y, z}
+}
+
+// drawAxisLabels renders the X/Y/Z glyphs billboarded to face cam. It
+// computes its own view/projection from cam rather than taking the
+// ones already in flight in the render loop, so it stays correct even
+// when the loop is previewing the scene from the light's point of view.
+// It updates the shared Matrices UBO with its own matrices for the
+// duration of the draw; callers that rely on the render loop's view/
+// projection must refresh the UBO again afterward.
+func drawAxisLabels(cam *Camera) {
+	view := cam.ViewMatrix()
+	projection := cam.ProjectionMatrix(aspectRatio(fbWidth, fbHeight), nearPlane, farPlane)
+	updateMatricesUBO(matricesUBO, projection, view)
+	for _, label := range axisLabels {
+		label.Draw(view)
+	}
+}
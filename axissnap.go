@@ -0,0 +1,58 @@
+package main
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// axisSnapDistance is how far from the scene origin each axis-snap view
+// (see snapToAxisView) places the camera - far enough to clear the demo
+// cube/grid at their default scale without needing frameAll's bounds
+// math.
+const axisSnapDistance = 10.0
+
+// AxisView names one of the six principal-axis CAD views snapToAxisView
+// can jump the camera to, numpad-bound in keyCallback the way front/
+// right/top are in most 3D tools (KP1/KP3/KP7, Ctrl+ the same keys for
+// the opposite face).
+type AxisView int
+
+const (
+	AxisViewFront AxisView = iota
+	AxisViewBack
+	AxisViewRight
+	AxisViewLeft
+	AxisViewTop
+	AxisViewBottom
+)
+
+// axisSnapPose is where the camera sits and which way it looks for one
+// AxisView, expressed as yaw/pitch (see frontFromYawPitch) so it plugs
+// straight into Camera.StartReset the same way the Reset key's home
+// view does.
+type axisSnapPose struct {
+	position   mgl32.Vec3
+	yaw, pitch float32
+}
+
+// axisSnapViews gives each AxisView's pose: the camera sits
+// axisSnapDistance out along that axis and looks back at the origin.
+// Top/Bottom use pitch just short of +-90 rather than exactly that,
+// the same workaround topCamera/ProcessMouse's pitch clamp use to avoid
+// the Front-parallel-to-Up degenerate case.
+var axisSnapViews = map[AxisView]axisSnapPose{
+	AxisViewFront:  {position: mgl32.Vec3{0, 0, axisSnapDistance}, yaw: -90, pitch: 0},
+	AxisViewBack:   {position: mgl32.Vec3{0, 0, -axisSnapDistance}, yaw: 90, pitch: 0},
+	AxisViewRight:  {position: mgl32.Vec3{axisSnapDistance, 0, 0}, yaw: 180, pitch: 0},
+	AxisViewLeft:   {position: mgl32.Vec3{-axisSnapDistance, 0, 0}, yaw: 0, pitch: 0},
+	AxisViewTop:    {position: mgl32.Vec3{0, axisSnapDistance, 0}, yaw: -90, pitch: -89.9},
+	AxisViewBottom: {position: mgl32.Vec3{0, -axisSnapDistance, 0}, yaw: -90, pitch: 89.9},
+}
+
+// snapToAxisView moves c to view via StartReset, so it respects
+// AnimateReset/ResetDuration the same as the Reset key's home view
+// instead of always snapping instantly, and leaves c.Fov untouched.
+func snapToAxisView(c *Camera, view AxisView) {
+	pose, ok := axisSnapViews[view]
+	if !ok {
+		return
+	}
+	c.StartReset(pose.position, pose.yaw, pose.pitch, c.Fov)
+}
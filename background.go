@@ -0,0 +1,124 @@
+package main
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// gradientEnabled/gradientTop/gradientBottom drive the optional gradient
+// background (see -bg-top/-bg-bottom in main.go). When disabled, the
+// plain -bg/defaultBGColor clear color is the whole background, same as
+// before this existed.
+var (
+	gradientEnabled bool
+	gradientTop     [3]float32
+	gradientBottom  [3]float32
+)
+
+var (
+	backgroundVAO, backgroundVBO uint32
+	backgroundProgram            uint32
+	backgroundTopUniform         int32
+	backgroundBottomUniform      int32
+)
+
+// initBackground compiles the gradient shader and uploads a clip-space
+// full-screen quad, the same two triangles PostPipeline.buildQuad draws
+// the resolved scene onto. Called once from Init, and only when -bg-top
+// is set and parses as a color.
+func initBackground() {
+	program, err := newProgram(backgroundVertexShader, backgroundFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	backgroundProgram = program
+	backgroundTopUniform = gl.GetUniformLocation(program, gl.Str("topColor\x00"))
+	backgroundBottomUniform = gl.GetUniformLocation(program, gl.Str("bottomColor\x00"))
+
+	vertices := []float32{
+		-1, -1,
+		1, -1,
+		1, 1,
+		-1, -1,
+		1, 1,
+		-1, 1,
+	}
+	gl.GenVertexArrays(1, &backgroundVAO)
+	trackCreate("vao")
+	resources.Register(ResourceVAO, backgroundVAO)
+	gl.GenBuffers(1, &backgroundVBO)
+	trackCreate("buffer")
+	resources.Register(ResourceBuffer, backgroundVBO)
+
+	gl.BindVertexArray(backgroundVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, backgroundVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, safeGLPtr(vertices), gl.STATIC_DRAW)
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, 2*4, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.BindVertexArray(0)
+}
+
+// drawBackground paints the gradient over the whole current viewport
+// before any scene geometry, so it works the same for the single
+// viewport and each pane of the quad layout (see drawSceneCore, which
+// calls this first - each caller has already set gl.Viewport for its
+// own pane). Depth testing is disabled for the draw so the quad doesn't
+// need its own depth value at all, and the depth buffer a pane just
+// cleared to 1.0 is left untouched for every real object to test against.
+func drawBackground() {
+	if !gradientEnabled {
+		return
+	}
+	gl.Disable(gl.DEPTH_TEST)
+	gl.UseProgram(backgroundProgram)
+	gl.Uniform3f(backgroundTopUniform, gradientTop[0], gradientTop[1], gradientTop[2])
+	gl.Uniform3f(backgroundBottomUniform, gradientBottom[0], gradientBottom[1], gradientBottom[2])
+	gl.BindVertexArray(backgroundVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+// deleteBackground frees the gradient quad's GL resources. Its VAO/VBO
+// are also registered with resources, so this only matters for the
+// trackCreate/trackDelete leak counters (see reportLeaks); DeleteAll
+// handles the actual GL deletion at shutdown either way.
+func deleteBackground() {
+	if backgroundVAO == 0 {
+		return
+	}
+	gl.DeleteVertexArrays(1, &backgroundVAO)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &backgroundVBO)
+	trackDelete("buffer")
+	gl.DeleteProgram(backgroundProgram)
+	trackDelete("program")
+}
+
+// backgroundVertexShader passes each corner of the clip-space quad
+// through untouched; the gradient only needs aPos.y, so there's no UV
+// attribute here unlike PostPipeline's quad.
+const backgroundVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 aPos;
+
+out float vY;
+
+void main() {
+	gl_Position = vec4(aPos, 0.0, 1.0);
+	vY = aPos.y;
+}
+` + "\x00"
+
+// backgroundFragmentShader linearly interpolates between topColor and
+// bottomColor by screen-space height, mapping vY from [-1, 1] to [0, 1].
+const backgroundFragmentShader = `
+#version 460 core
+in float vY;
+out vec4 FragColor;
+
+uniform vec3 topColor;
+uniform vec3 bottomColor;
+
+void main() {
+	float t = vY * 0.5 + 0.5;
+	FragColor = vec4(mix(bottomColor, topColor, t), 1.0);
+}
+` + "\x00"
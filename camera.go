@@ -0,0 +1,773 @@
+package main
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Direction is a discrete movement direction for Camera.ProcessKeyboard.
+type Direction int
+
+const (
+	DirectionForward Direction = iota
+	DirectionBackward
+	DirectionLeft
+	DirectionRight
+)
+
+// Camera holds a fly camera's pose and movement tuning. It used to be a
+// handful of package-level globals in main.go; pulling it into its own
+// type means a camera can be constructed and its ViewMatrix tested
+// without a window or GL context, and nothing stops a caller from
+// holding more than one.
+type Camera struct {
+	Position mgl32.Vec3
+	Front    mgl32.Vec3
+	Up       mgl32.Vec3
+	Yaw      float32
+	Pitch    float32
+	Fov      float32
+	Speed    float32
+
+	// TargetFov is what Fov eases towards each frame; see ProcessScroll
+	// and UpdateZoom.
+	TargetFov float32
+
+	// ScrollSensitivity and ScrollSmoothing tune how ProcessScroll/
+	// UpdateZoom map wheel input to zoom: ScrollSensitivity scales the raw
+	// wheel delta before it moves TargetFov, and ScrollSmoothing is how
+	// quickly Fov eases towards TargetFov each frame, in [0, 1] (1
+	// disables smoothing entirely).
+	ScrollSensitivity float32
+	ScrollSmoothing   float32
+
+	// Orthographic switches ProjectionMatrix from mgl32.Perspective to
+	// mgl32.Ortho. OrthoHalfHeight/TargetOrthoHalfHeight are ortho's
+	// equivalent of Fov/TargetFov: ProcessScroll scales the extents
+	// instead of the fov while in this mode, eased the same way by
+	// UpdateZoom.
+	Orthographic          bool
+	OrthoHalfHeight       float32
+	TargetOrthoHalfHeight float32
+
+	// OrbitEnabled switches the camera from free-fly to turntable mode:
+	// Position is recomputed each frame (UpdateOrbit) as OrbitTarget plus
+	// a point on a sphere of OrbitRadius at the current yaw/pitch, rather
+	// than being moved directly. Scroll controls OrbitRadius instead of
+	// zoom, and WASD (via PanTarget) slides OrbitTarget across the ground
+	// plane instead of moving the camera.
+	OrbitEnabled      bool
+	OrbitTarget       mgl32.Vec3
+	OrbitRadius       float32
+	TargetOrbitRadius float32
+
+	// CursorZoom switches scrolling from the fov/radius-based zoom in
+	// ProcessScroll to ProcessScrollAt's cursor-anchored zoom, which keeps
+	// the point under the mouse fixed on screen instead of zooming about
+	// the screen center.
+	CursorZoom bool
+
+	// Velocity is the camera's current world-space speed, eased toward
+	// Speed along the input direction by Move/MoveVertical/ProcessMovement
+	// (see accelerate) rather than snapping straight to it, and decayed
+	// back toward zero when there's no input.
+	Velocity mgl32.Vec3
+
+	// Acceleration and Damping are how quickly Velocity ramps up toward a
+	// new input direction and decays back to rest once input stops, in
+	// 1/s (so Acceleration*dt == 1 reaches the target speed in a single
+	// frame, same clamp as ScrollSmoothing's [0, 1] easing).
+	Acceleration float32
+	Damping      float32
+
+	// LookSmoothing eases ProcessMouse's yaw/pitch toward TargetYaw/
+	// TargetPitch over UpdateLook calls instead of applying mouse deltas
+	// immediately, so a fast flick settles instead of snapping the view.
+	LookSmoothing       bool
+	TargetYaw           float32
+	TargetPitch         float32
+	LookSmoothingFactor float32
+
+	// Sensitivity scales a raw mouse delta (pixels moved) before
+	// ProcessMouse applies it to yaw/pitch/Orientation; mouseCallback
+	// multiplies by this instead of a hardcoded constant, so it can be
+	// tuned per camera and persisted with camera state.
+	Sensitivity float32
+
+	// InvertY negates ProcessMouse's dy before it's applied, flipping
+	// pitch direction for players who prefer "pull down to look up".
+	InvertY bool
+
+	// QuaternionMode switches the camera's orientation from yaw/pitch
+	// Euler angles (the default, clamped to ±89° pitch to avoid gimbal
+	// flip, with no roll axis) to a quaternion, enabling full 6-DOF
+	// "spaceship" navigation - including roll (see Roll) - with no pitch
+	// clamp and no gimbal lock. ProcessMouse branches on this; Front/Up
+	// stay in sync either way, so ViewMatrix doesn't need to know which
+	// mode is active. Set once at startup from the -quatcam flag; nothing
+	// stops a caller from flipping it later, but Orientation is only kept
+	// in sync with Front/Up at construction and Reset, not every Euler
+	// ProcessMouse call, so switching modes mid-flight can jump the view.
+	QuaternionMode bool
+
+	// Orientation is the camera's rotation as a quaternion, used in place
+	// of Yaw/Pitch while QuaternionMode is set.
+	Orientation mgl32.Quat
+
+	// RollSpeed is how many degrees/sec Roll rotates the camera about its
+	// own Front axis at full input (QuaternionMode only).
+	RollSpeed float32
+
+	// WalkMode switches Move/ProcessMovement's forward/strafe axes from
+	// the camera's full look direction to its flattened (Y-zeroed) one,
+	// so looking up or down doesn't pitch the walk direction into the
+	// ground or sky - the common "walk on the ground" feel for
+	// architectural walkthroughs, as opposed to the default fly mode.
+	// Vertical movement (MoveVertical, or ProcessMovement's vertical
+	// axis - Space/Shift in processInput) is unaffected either way.
+	WalkMode bool
+
+	// VerticalLocalUp switches MoveVertical/ProcessMovement's vertical
+	// axis (Space/Shift in processInput) from true world up {0, 1, 0} to
+	// the camera's own current Up, which only differs from world up in
+	// QuaternionMode after a roll. False (the default) matches the
+	// camera's long-standing behavior: Space/Shift always rise/fall
+	// along world up regardless of orientation. Set once from the
+	// -vertical-local-up flag; nothing stops a caller from flipping it
+	// later.
+	VerticalLocalUp bool
+
+	// PlaneLock constrains movement to one of the three coordinate
+	// planes and snaps orientation to look perpendicular into it, for
+	// 2D-style inspection; see PlaneLock and SetPlaneLock. Set via
+	// SetPlaneLock, never assigned directly, since entering a lock also
+	// needs to move Yaw/Pitch - assigning the field alone would restrict
+	// movement without actually reorienting the camera.
+	PlaneLock PlaneLock
+
+	// MovementFrame selects which axes processInput's WASD forward/
+	// strafe values move along, overriding the OrbitEnabled-based
+	// choice between ProcessMovement and PanTarget that used to be the
+	// only option. MovementFrameCamera (the default) preserves that
+	// original behavior exactly; see MovementFrame's own doc comment
+	// for the other two. Set once from the -movement-frame flag;
+	// nothing stops a caller from flipping it later.
+	MovementFrame MovementFrame
+
+	// AnimateReset switches StartReset from an instant snap to an eased
+	// transition over ResetDuration seconds, set once from the
+	// -animate-reset flag.
+	AnimateReset bool
+
+	// ResetDuration is how long StartReset's eased transition takes, in
+	// seconds, when AnimateReset is set.
+	ResetDuration float32
+
+	// resetAnimating/resetElapsed/reset{From,To}* track an in-flight
+	// StartReset transition; see UpdateResetAnimation.
+	resetAnimating  bool
+	resetElapsed    float32
+	resetFromPos    mgl32.Vec3
+	resetFromYaw    float32
+	resetFromPitch  float32
+	resetFromFov    float32
+	resetFromOrient mgl32.Quat
+	resetToPos      mgl32.Vec3
+	resetToYaw      float32
+	resetToPitch    float32
+	resetToFov      float32
+	resetToOrient   mgl32.Quat
+}
+
+// NewCamera builds a camera at position, facing the given yaw/pitch, with
+// fov as both its current and target zoom.
+func NewCamera(position mgl32.Vec3, yaw, pitch, fov float32) *Camera {
+	c := &Camera{
+		Position:          position,
+		Up:                mgl32.Vec3{0, 1, 0},
+		Yaw:               yaw,
+		Pitch:             pitch,
+		Fov:               fov,
+		Speed:             4,
+		TargetFov:         fov,
+		ScrollSensitivity: 1.0,
+		ScrollSmoothing:   0.2,
+		Sensitivity:       0.1,
+		OrbitTarget:       mgl32.Vec3{0, 0, 0},
+
+		Acceleration: 10,
+		Damping:      8,
+
+		TargetYaw:           yaw,
+		TargetPitch:         pitch,
+		LookSmoothingFactor: 0.3,
+
+		RollSpeed: 60,
+
+		ResetDuration: 0.3,
+	}
+	c.updateVectors()
+	c.Orientation = quatFromFrontUp(c.Front, c.Up)
+	c.OrbitRadius = c.Position.Sub(c.OrbitTarget).Len()
+	c.TargetOrbitRadius = c.OrbitRadius
+	return c
+}
+
+// quatFromFrontUp builds the quaternion that rotates the camera's local
+// axes (right = +X, up = +Y, front = -Z) onto the given front/up world
+// vectors, so QuaternionMode can start from whatever pose Euler mode was
+// in rather than snapping to identity.
+func quatFromFrontUp(front, up mgl32.Vec3) mgl32.Quat {
+	front = front.Normalize()
+	right := front.Cross(up).Normalize()
+	trueUp := right.Cross(front)
+	rot := mgl32.Mat4{
+		right.X(), right.Y(), right.Z(), 0,
+		trueUp.X(), trueUp.Y(), trueUp.Z(), 0,
+		-front.X(), -front.Y(), -front.Z(), 0,
+		0, 0, 0, 1,
+	}
+	return mgl32.Mat4ToQuat(rot)
+}
+
+// ViewMatrix returns the camera's current view matrix.
+func (c *Camera) ViewMatrix() mgl32.Mat4 {
+	return mgl32.LookAtV(c.Position, c.Position.Add(c.Front), c.Up)
+}
+
+// ProjectionMatrix returns a perspective or orthographic projection
+// depending on Orthographic, for the given aspect ratio and clip planes.
+func (c *Camera) ProjectionMatrix(aspect, near, far float32) mgl32.Mat4 {
+	if c.Orthographic {
+		halfHeight := c.OrthoHalfHeight
+		halfWidth := halfHeight * aspect
+		return mgl32.Ortho(-halfWidth, halfWidth, -halfHeight, halfHeight, near, far)
+	}
+	return mgl32.Perspective(mgl32.DegToRad(c.Fov), aspect, near, far)
+}
+
+// ToggleProjection flips between perspective and orthographic. Switching
+// into ortho derives OrthoHalfHeight from the current fov and the
+// camera's distance from the origin, so the scene roughly fills the same
+// frame it did under perspective instead of jumping to an arbitrary
+// zoom level.
+func (c *Camera) ToggleProjection() {
+	c.Orthographic = !c.Orthographic
+	if c.Orthographic {
+		distance := c.Position.Len()
+		if distance < 1 {
+			distance = 1
+		}
+		halfHeight := distance * float32(math.Tan(float64(mgl32.DegToRad(c.Fov))/2))
+		c.OrthoHalfHeight = halfHeight
+		c.TargetOrthoHalfHeight = halfHeight
+	}
+}
+
+// accelerate eases Velocity toward direction normalized and scaled by
+// Speed, or decays it toward zero when direction is the zero vector,
+// then integrates the result into Position. Move, MoveVertical, and
+// ProcessMovement all fold their input into a single direction and call
+// this once per frame, so idle axes don't stomp on one that's still
+// accelerating or coasting.
+func (c *Camera) accelerate(direction mgl32.Vec3, dt float32) {
+	if direction.Len() > 0 {
+		target := direction.Normalize().Mul(c.Speed)
+		c.Velocity = c.Velocity.Add(target.Sub(c.Velocity).Mul(clamp32(c.Acceleration*dt, 0, 1)))
+	} else {
+		c.Velocity = c.Velocity.Sub(c.Velocity.Mul(clamp32(c.Damping*dt, 0, 1)))
+	}
+	c.Position = c.Position.Add(c.Velocity.Mul(dt))
+}
+
+// Move advances the camera along its own forward/right axes, easing
+// Velocity toward Speed in that direction rather than snapping straight
+// to it (see accelerate). forward and strafe are typically in [-1, 1]
+// and may be the sum of more than one input source in the same frame
+// (keyboard and gamepad).
+func (c *Camera) Move(forward, strafe, dt float32) {
+	if forward != 0 || strafe != 0 {
+		c.cancelResetAnimation()
+	}
+	front, right := c.walkAxes()
+	direction := front.Mul(forward).Add(right.Mul(strafe))
+	c.accelerate(direction, dt)
+}
+
+// walkAxes returns the front/right axes Move and ProcessMovement should
+// use: Front and its cross with Up as-is in fly mode, both flattened to
+// the Y=0 ground plane in WalkMode, or - while PlaneLock is set - the
+// locked plane's own two in-plane axes instead of anything derived from
+// Front at all, so forward/back pans within the plane rather than
+// towards/away from it (which is what real Front would do, since
+// SetPlaneLock points the camera straight at the plane).
+func (c *Camera) walkAxes() (front, right mgl32.Vec3) {
+	if pose, ok := planeLockPoses[c.PlaneLock]; ok {
+		return pose.moveFront, pose.moveRight
+	}
+	front, right = c.Front, c.Front.Cross(c.Up).Normalize()
+	if c.WalkMode {
+		front, right = flattenXZ(front), flattenXZ(right)
+	}
+	return front, right
+}
+
+// PlaneLock constrains Camera to translating within one of the three
+// coordinate planes and looking perpendicular into it - a constrained
+// 2D pan view, e.g. for inspecting a flat layout or blueprint. Pair it
+// with Orthographic for a true blueprint view with no perspective
+// foreshortening. PlaneLockNone (the default) leaves movement and
+// orientation unconstrained.
+type PlaneLock int
+
+const (
+	PlaneLockNone PlaneLock = iota
+	PlaneLockXY
+	PlaneLockXZ
+	PlaneLockYZ
+)
+
+// Next cycles to the next plane lock, wrapping back to PlaneLockNone.
+func (p PlaneLock) Next() PlaneLock {
+	return (p + 1) % 4
+}
+
+func (p PlaneLock) String() string {
+	switch p {
+	case PlaneLockXY:
+		return "XY"
+	case PlaneLockXZ:
+		return "XZ"
+	case PlaneLockYZ:
+		return "YZ"
+	default:
+		return "none"
+	}
+}
+
+// planeLockPoses gives each lock's yaw/pitch (the orientation that looks
+// perpendicular into the plane) and its two in-plane movement axes
+// (moveFront/moveRight, read by walkAxes in place of Front itself).
+// XZ's pitch is -89, not a true -90, since Front would otherwise be
+// exactly parallel to the fixed Up {0, 1, 0} (see Camera.Up), the
+// classic gimbal-lock case the ordinary ±89° ProcessMouse clamp also
+// avoids - effectively perpendicular reads the same to a viewer as
+// exactly perpendicular.
+var planeLockPoses = map[PlaneLock]struct {
+	yaw, pitch           float32
+	moveFront, moveRight mgl32.Vec3
+}{
+	PlaneLockXY: {yaw: -90, pitch: 0, moveFront: mgl32.Vec3{0, 1, 0}, moveRight: mgl32.Vec3{1, 0, 0}},
+	PlaneLockXZ: {yaw: -90, pitch: -89, moveFront: mgl32.Vec3{0, 0, -1}, moveRight: mgl32.Vec3{1, 0, 0}},
+	PlaneLockYZ: {yaw: 180, pitch: 0, moveFront: mgl32.Vec3{0, 1, 0}, moveRight: mgl32.Vec3{0, 0, -1}},
+}
+
+// SetPlaneLock applies lock: PlaneLockNone just stops restricting
+// movement, leaving Yaw/Pitch wherever they were (there's no single
+// "free-fly" pose to snap back to); any other value also snaps
+// orientation to planeLockPoses' perpendicular view, same as an eased
+// StartReset would, but instant since a mode switch like this is
+// expected to cut the view immediately.
+func (c *Camera) SetPlaneLock(lock PlaneLock) {
+	c.PlaneLock = lock
+	pose, ok := planeLockPoses[lock]
+	if !ok {
+		return
+	}
+	c.Yaw, c.Pitch = pose.yaw, pose.pitch
+	c.Up = mgl32.Vec3{0, 1, 0}
+	c.updateVectors()
+}
+
+// MoveVertical moves the camera straight up or down along world Up (or
+// the camera's own Up, if VerticalLocalUp is set), independent of look
+// direction, the same eased way as Move. It exists for inputs with no
+// keyboard equivalent, like gamepad triggers.
+func (c *Camera) MoveVertical(amount, dt float32) {
+	if amount != 0 {
+		c.cancelResetAnimation()
+	}
+	c.accelerate(c.verticalAxis().Mul(amount), dt)
+}
+
+// verticalAxis is the axis Space/Shift move along: world up by default,
+// or the camera's own (possibly rolled) Up if VerticalLocalUp is set -
+// or the zero vector while PlaneLock is set, since Space/Shift have no
+// in-plane meaning and letting them move the camera off the plane would
+// defeat the point of locking it to one.
+func (c *Camera) verticalAxis() mgl32.Vec3 {
+	if c.PlaneLock != PlaneLockNone {
+		return mgl32.Vec3{}
+	}
+	if c.VerticalLocalUp {
+		return c.Up
+	}
+	return mgl32.Vec3{0, 1, 0}
+}
+
+// MovementFrame selects which axes Camera's WASD-style movement uses;
+// see Camera.MovementFrame and processInput, which branches on it.
+type MovementFrame int
+
+const (
+	// MovementFrameCamera moves along the camera's own forward/right
+	// axes (walkAxes, via ProcessMovement) - or, while OrbitEnabled,
+	// pans OrbitTarget along those same flattened axes (PanTarget)
+	// instead of moving the camera itself. This is the default and
+	// matches the camera's behavior before MovementFrame existed.
+	MovementFrameCamera MovementFrame = iota
+
+	// MovementFrameWorld moves along fixed world X/Z (MoveWorld),
+	// regardless of OrbitEnabled or which way the camera is looking -
+	// e.g. for an overhead map editor where WASD should mean "north/
+	// south/east/west" rather than "forward/back relative to view."
+	MovementFrameWorld
+
+	// MovementFrameTargetPan always pans OrbitTarget (PanTarget), even
+	// outside orbit mode, so WASD repositions what the camera is
+	// looking at rather than the camera itself.
+	MovementFrameTargetPan
+)
+
+// MoveWorld advances the camera along fixed world X/Z axes - east for
+// positive strafe, south for positive forward - ignoring the camera's
+// own look direction entirely, unlike Move/ProcessMovement's walkAxes.
+// Vertical still follows verticalAxis, same as ProcessMovement.
+func (c *Camera) MoveWorld(forward, strafe, vertical, dt float32) {
+	if forward != 0 || strafe != 0 || vertical != 0 {
+		c.cancelResetAnimation()
+	}
+	direction := mgl32.Vec3{strafe, 0, -forward}.Add(c.verticalAxis().Mul(vertical))
+	c.accelerate(direction, dt)
+}
+
+// ProcessMovement is Move and MoveVertical fused into one accelerate
+// call, for callers (like processInput) that gather forward/strafe/
+// vertical input in the same frame: calling Move and MoveVertical
+// separately would have each one's idle-axis damping fight the other's
+// acceleration, since they'd otherwise share the same Velocity.
+func (c *Camera) ProcessMovement(forward, strafe, vertical, dt float32) {
+	if forward != 0 || strafe != 0 || vertical != 0 {
+		c.cancelResetAnimation()
+	}
+	front, right := c.walkAxes()
+	direction := front.Mul(forward).Add(right.Mul(strafe)).Add(c.verticalAxis().Mul(vertical))
+	c.accelerate(direction, dt)
+}
+
+// ProcessKeyboard moves the camera one discrete direction at a time. For
+// fused analog input from multiple sources in the same frame, call Move
+// directly instead.
+func (c *Camera) ProcessKeyboard(dir Direction, dt float32) {
+	switch dir {
+	case DirectionForward:
+		c.Move(1, 0, dt)
+	case DirectionBackward:
+		c.Move(-1, 0, dt)
+	case DirectionRight:
+		c.Move(0, 1, dt)
+	case DirectionLeft:
+		c.Move(0, -1, dt)
+	}
+}
+
+// ProcessMouse applies a mouse-look delta, already scaled by whatever
+// sensitivity the caller wants, to yaw/pitch, clamping pitch to avoid
+// gimbal flip. When LookSmoothing is enabled, the delta moves
+// TargetYaw/TargetPitch instead, and UpdateLook eases Yaw/Pitch towards
+// them each frame; otherwise it's applied immediately, as before.
+func (c *Camera) ProcessMouse(dx, dy float32) {
+	if dx != 0 || dy != 0 {
+		c.cancelResetAnimation()
+	}
+	if c.QuaternionMode {
+		c.rotateOrientation(dx, dy)
+		return
+	}
+	if c.LookSmoothing {
+		c.TargetYaw += dx
+		c.TargetPitch = clamp32(c.TargetPitch+dy, -89, 89)
+		return
+	}
+	c.Yaw += dx
+	c.Pitch += dy
+	if c.Pitch > 89 {
+		c.Pitch = 89
+	}
+	if c.Pitch < -89 {
+		c.Pitch = -89
+	}
+	c.updateVectors()
+}
+
+// rotateOrientation applies a mouse-look delta as incremental rotations
+// about the camera's current local right (pitch) and local up (yaw)
+// axes, rather than Euler mode's world-up yaw: rotating about the local
+// axes is what lets repeated turns compose correctly - without snapping
+// back level - once Roll has tilted the camera off the horizon.
+func (c *Camera) rotateOrientation(dx, dy float32) {
+	right := c.Orientation.Rotate(mgl32.Vec3{1, 0, 0})
+	up := c.Orientation.Rotate(mgl32.Vec3{0, 1, 0})
+	yaw := mgl32.QuatRotate(mgl32.DegToRad(-dx), up)
+	pitch := mgl32.QuatRotate(mgl32.DegToRad(dy), right)
+	c.Orientation = yaw.Mul(pitch).Mul(c.Orientation).Normalize()
+	c.syncFromOrientation()
+}
+
+// Roll rotates the camera about its own Front axis, the one axis Euler
+// mode has no room for; it's a no-op outside QuaternionMode. amount is
+// typically -1/0/1, matching the sign convention of Move's forward/
+// strafe inputs, scaled by RollSpeed and dt the same way accelerate
+// scales Speed by dt.
+func (c *Camera) Roll(amount, dt float32) {
+	if !c.QuaternionMode || amount == 0 {
+		return
+	}
+	c.cancelResetAnimation()
+	front := c.Orientation.Rotate(mgl32.Vec3{0, 0, -1})
+	roll := mgl32.QuatRotate(mgl32.DegToRad(c.RollSpeed*amount*dt), front)
+	c.Orientation = roll.Mul(c.Orientation).Normalize()
+	c.syncFromOrientation()
+}
+
+// syncFromOrientation recomputes Front/Up from Orientation, so
+// ViewMatrix and the rest of Camera's movement code - all written in
+// terms of Front/Up - work unchanged in QuaternionMode.
+func (c *Camera) syncFromOrientation() {
+	c.Front = c.Orientation.Rotate(mgl32.Vec3{0, 0, -1}).Normalize()
+	c.Up = c.Orientation.Rotate(mgl32.Vec3{0, 1, 0}).Normalize()
+}
+
+// UpdateLook eases Yaw/Pitch toward TargetYaw/TargetPitch by
+// LookSmoothingFactor each frame. It's a no-op unless LookSmoothing is
+// enabled; the caller should run it every frame alongside UpdateZoom
+// regardless, the same way UpdateZoom no-ops when nothing's mid-ease.
+func (c *Camera) UpdateLook() {
+	if !c.LookSmoothing {
+		return
+	}
+	c.Yaw += (c.TargetYaw - c.Yaw) * c.LookSmoothingFactor
+	c.Pitch += (c.TargetPitch - c.Pitch) * c.LookSmoothingFactor
+	c.updateVectors()
+}
+
+// ProcessScroll nudges the zoom target by a wheel delta, scaled by
+// ScrollSensitivity; UpdateZoom eases towards it each frame. In
+// orthographic mode this scales TargetOrthoHalfHeight proportionally
+// instead of moving TargetFov, since fov has no meaning without
+// perspective.
+func (c *Camera) ProcessScroll(dy float32) {
+	c.cancelResetAnimation()
+	if c.OrbitEnabled {
+		c.TargetOrbitRadius -= dy * c.ScrollSensitivity * c.TargetOrbitRadius * 0.1
+		if c.TargetOrbitRadius < 0.1 {
+			c.TargetOrbitRadius = 0.1
+		}
+		return
+	}
+	if c.Orthographic {
+		c.TargetOrthoHalfHeight -= dy * c.ScrollSensitivity * c.TargetOrthoHalfHeight * 0.1
+		if c.TargetOrthoHalfHeight < 0.1 {
+			c.TargetOrthoHalfHeight = 0.1
+		}
+		return
+	}
+	c.TargetFov -= dy * c.ScrollSensitivity
+	c.TargetFov = clamp32(c.TargetFov, fovMin, fovMax)
+}
+
+// ProcessScrollAt is ProcessScroll's cursor-anchored counterpart: when
+// CursorZoom is enabled, it moves the camera (or, in orbit mode, the
+// orbit target) along rayDir instead of narrowing Fov/OrthoHalfHeight
+// about the screen center, so the point under the cursor stays fixed on
+// screen as the view zooms. rayOrigin/rayDir should come from
+// ScreenPointToRay for the cursor's current position. When CursorZoom is
+// disabled, it just calls ProcessScroll.
+func (c *Camera) ProcessScrollAt(dy float32, rayOrigin, rayDir mgl32.Vec3) {
+	if !c.CursorZoom {
+		c.ProcessScroll(dy)
+		return
+	}
+	c.cancelResetAnimation()
+	if c.OrbitEnabled {
+		step := dy * c.ScrollSensitivity * c.TargetOrbitRadius * 0.1
+		c.OrbitTarget = c.OrbitTarget.Add(rayDir.Mul(step))
+		c.TargetOrbitRadius -= step
+		if c.TargetOrbitRadius < 0.1 {
+			c.TargetOrbitRadius = 0.1
+		}
+		return
+	}
+	// Free-fly: dolly toward wherever the cursor ray meets the ground,
+	// scaled by distance so the step feels consistent near and far, same
+	// as the proportional scaling ProcessScroll uses for fov/radius.
+	distance := c.Speed * 4
+	if hit, ok := IntersectGroundPlane(rayOrigin, rayDir); ok {
+		distance = hit.Sub(c.Position).Len()
+	}
+	step := dy * c.ScrollSensitivity * distance * 0.1
+	c.Position = c.Position.Add(rayDir.Mul(step))
+}
+
+// UpdateZoom eases Fov towards TargetFov, OrthoHalfHeight towards
+// TargetOrthoHalfHeight, and OrbitRadius towards TargetOrbitRadius. Call
+// once per frame.
+func (c *Camera) UpdateZoom() {
+	c.Fov += (c.TargetFov - c.Fov) * c.ScrollSmoothing
+	c.OrthoHalfHeight += (c.TargetOrthoHalfHeight - c.OrthoHalfHeight) * c.ScrollSmoothing
+	c.OrbitRadius += (c.TargetOrbitRadius - c.OrbitRadius) * c.ScrollSmoothing
+}
+
+// ToggleOrbit switches between free-fly and turntable mode. Turning
+// orbit on re-anchors OrbitRadius to the camera's current distance from
+// OrbitTarget, so the view doesn't jump; the target itself (default the
+// origin) and the yaw/pitch look direction carry over unchanged.
+func (c *Camera) ToggleOrbit() {
+	c.OrbitEnabled = !c.OrbitEnabled
+	if c.OrbitEnabled {
+		c.OrbitRadius = c.Position.Sub(c.OrbitTarget).Len()
+		c.TargetOrbitRadius = c.OrbitRadius
+	}
+}
+
+// UpdateOrbit recomputes Position from OrbitTarget, OrbitRadius, and the
+// current yaw/pitch (via Front), placing the camera on a sphere around
+// the target and looking inward. No-op unless OrbitEnabled. Call once
+// per frame, after UpdateZoom has eased OrbitRadius.
+func (c *Camera) UpdateOrbit() {
+	if !c.OrbitEnabled {
+		return
+	}
+	c.Position = c.OrbitTarget.Sub(c.Front.Mul(c.OrbitRadius))
+}
+
+// PanTarget slides OrbitTarget across the ground plane using the
+// camera's flattened (Y-zeroed) front/right axes, the orbit-mode
+// equivalent of Move. forward/strafe follow the same [-1, 1] convention.
+func (c *Camera) PanTarget(forward, strafe, dt float32) {
+	if forward != 0 || strafe != 0 {
+		c.cancelResetAnimation()
+	}
+	velocity := c.Speed * dt
+	flatFront := flattenXZ(c.Front)
+	flatRight := flattenXZ(c.Front.Cross(c.Up).Normalize())
+	c.OrbitTarget = c.OrbitTarget.Add(flatFront.Mul(forward * velocity))
+	c.OrbitTarget = c.OrbitTarget.Add(flatRight.Mul(strafe * velocity))
+}
+
+// flattenXZ projects v onto the Y=0 ground plane, returning the zero
+// vector (rather than dividing by zero) if v is already vertical.
+func flattenXZ(v mgl32.Vec3) mgl32.Vec3 {
+	flat := mgl32.Vec3{v.X(), 0, v.Z()}
+	if flat.Len() == 0 {
+		return flat
+	}
+	return flat.Normalize()
+}
+
+// Reset snaps the camera straight to a pose, bypassing zoom easing, e.g.
+// for a "home" key that should feel instant rather than eased.
+func (c *Camera) Reset(position mgl32.Vec3, yaw, pitch, fov float32) {
+	c.Position = position
+	c.Velocity = mgl32.Vec3{}
+	c.Yaw = yaw
+	c.Pitch = pitch
+	c.TargetYaw = yaw
+	c.TargetPitch = pitch
+	c.Fov = fov
+	c.TargetFov = fov
+	c.Up = mgl32.Vec3{0, 1, 0}
+	c.updateVectors()
+	c.Orientation = quatFromFrontUp(c.Front, c.Up)
+	c.resetAnimating = false
+}
+
+// StartReset is Reset's eased counterpart: it snaps instantly, same as
+// Reset, unless AnimateReset is set, in which case it instead records the
+// camera's current pose as the animation's start and lets
+// UpdateResetAnimation lerp Position/Fov and slerp Orientation (or ease
+// Yaw/Pitch outside QuaternionMode) toward it over ResetDuration
+// seconds. Callers that want the "home" key to feel instant rather than
+// eased should call Reset directly instead.
+func (c *Camera) StartReset(position mgl32.Vec3, yaw, pitch, fov float32) {
+	if !c.AnimateReset || c.ResetDuration <= 0 {
+		c.Reset(position, yaw, pitch, fov)
+		return
+	}
+
+	c.resetFromPos = c.Position
+	c.resetFromYaw = c.Yaw
+	c.resetFromPitch = c.Pitch
+	c.resetFromFov = c.Fov
+	c.resetFromOrient = c.Orientation
+
+	c.resetToPos = position
+	c.resetToYaw = yaw
+	c.resetToPitch = pitch
+	c.resetToFov = fov
+	c.resetToOrient = quatFromFrontUp(frontFromYawPitch(yaw, pitch), mgl32.Vec3{0, 1, 0})
+
+	c.resetElapsed = 0
+	c.resetAnimating = true
+	c.Velocity = mgl32.Vec3{}
+}
+
+// UpdateResetAnimation advances an in-flight StartReset transition by dt,
+// smoothstepping progress so the camera settles into its home pose
+// instead of stopping abruptly. It's a no-op once nothing is animating,
+// so the render loop can call it unconditionally every frame alongside
+// UpdateZoom/UpdateLook/UpdateOrbit.
+func (c *Camera) UpdateResetAnimation(dt float32) {
+	if !c.resetAnimating {
+		return
+	}
+	c.resetElapsed += dt
+	t := smoothstep(c.resetElapsed / c.ResetDuration)
+
+	c.Position = lerpVec3(c.resetFromPos, c.resetToPos, t)
+	c.Fov = c.resetFromFov + (c.resetToFov-c.resetFromFov)*t
+	c.TargetFov = c.Fov
+
+	if c.QuaternionMode {
+		c.Orientation = mgl32.QuatSlerp(c.resetFromOrient, c.resetToOrient, t)
+		c.syncFromOrientation()
+	} else {
+		c.Yaw = c.resetFromYaw + (c.resetToYaw-c.resetFromYaw)*t
+		c.Pitch = c.resetFromPitch + (c.resetToPitch-c.resetFromPitch)*t
+		c.TargetYaw = c.Yaw
+		c.TargetPitch = c.Pitch
+		c.updateVectors()
+	}
+
+	if c.resetElapsed >= c.ResetDuration {
+		c.resetAnimating = false
+	}
+}
+
+// cancelResetAnimation stops an in-flight StartReset transition cleanly,
+// leaving the camera wherever it currently sits rather than snapping the
+// rest of the way to the target pose. Every input path that moves or
+// looks the camera calls this, so a reset animation never fights live
+// player input.
+func (c *Camera) cancelResetAnimation() {
+	c.resetAnimating = false
+}
+
+// frontFromYawPitch computes the front vector for a given yaw/pitch pair
+// without mutating a Camera, so StartReset can derive the reset target's
+// orientation ahead of actually moving there.
+func frontFromYawPitch(yaw, pitch float32) mgl32.Vec3 {
+	yawRad := mgl32.DegToRad(yaw)
+	pitchRad := mgl32.DegToRad(pitch)
+	front := mgl32.Vec3{
+		cos32(pitchRad) * cos32(yawRad),
+		sin32(pitchRad),
+		cos32(pitchRad) * sin32(yawRad),
+	}
+	return front.Normalize()
+}
+
+func (c *Camera) updateVectors() {
+	c.Front = frontFromYawPitch(c.Yaw, c.Pitch)
+}
@@ -0,0 +1,360 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestCameraViewMatrixLooksAlongFront(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 5}, -90, 0, 45)
+	view := c.ViewMatrix()
+
+	// Facing along -Z (yaw -90), the origin should land somewhere in
+	// front of the camera, i.e. at a negative view-space Z.
+	origin := view.Mul4x1(mgl32.Vec4{0, 0, 0, 1})
+	if origin.Z() >= 0 {
+		t.Errorf("origin view-space Z = %v, want negative (in front of camera)", origin.Z())
+	}
+}
+
+func TestCameraProcessKeyboardMovesAlongFront(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 0}, -90, 0, 45)
+	c.ProcessKeyboard(DirectionForward, 1.0)
+
+	want := c.Front.Mul(c.Speed)
+	got := c.Position
+	if got.Sub(want).Len() > 1e-4 {
+		t.Errorf("Position = %v, want %v", got, want)
+	}
+}
+
+func TestCameraProcessScrollClampsFov(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.ProcessScroll(-1000)
+	if c.TargetFov != 90 {
+		t.Errorf("TargetFov = %v, want clamped to 90", c.TargetFov)
+	}
+	c.ProcessScroll(1000)
+	if c.TargetFov != 1 {
+		t.Errorf("TargetFov = %v, want clamped to 1", c.TargetFov)
+	}
+}
+
+func TestCameraToggleProjectionSwitchesMatrixKind(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 10}, -90, 0, 45)
+	persp := c.ProjectionMatrix(16.0/9.0, 0.1, 100)
+
+	c.ToggleProjection()
+	if !c.Orthographic {
+		t.Fatal("ToggleProjection did not set Orthographic")
+	}
+	if c.OrthoHalfHeight <= 0 {
+		t.Errorf("OrthoHalfHeight = %v, want positive", c.OrthoHalfHeight)
+	}
+
+	ortho := c.ProjectionMatrix(16.0/9.0, 0.1, 100)
+	if persp == ortho {
+		t.Error("ProjectionMatrix returned the same matrix for perspective and ortho")
+	}
+
+	c.ToggleProjection()
+	if c.Orthographic {
+		t.Error("second ToggleProjection should return to perspective")
+	}
+}
+
+func TestCameraOrbitKeepsPositionOnSphereAroundTarget(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 10}, -90, 0, 45)
+	c.ToggleOrbit()
+	c.UpdateOrbit()
+
+	dist := c.Position.Sub(c.OrbitTarget).Len()
+	if diff := dist - c.OrbitRadius; diff > 1e-3 || diff < -1e-3 {
+		t.Errorf("distance to OrbitTarget = %v, want OrbitRadius = %v", dist, c.OrbitRadius)
+	}
+}
+
+func TestCameraPanTargetMovesAcrossGroundPlane(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 5, 10}, -90, -30, 45)
+	c.ToggleOrbit()
+
+	before := c.OrbitTarget
+	c.PanTarget(1, 0, 1.0)
+	if c.OrbitTarget.Y() != before.Y() {
+		t.Errorf("PanTarget changed Y from %v to %v, want it to stay on the ground plane", before.Y(), c.OrbitTarget.Y())
+	}
+	if c.OrbitTarget == before {
+		t.Error("PanTarget did not move OrbitTarget")
+	}
+}
+
+func TestCameraProcessScrollAtFallsBackWithoutCursorZoom(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 5}, -90, 0, 45)
+	c.ProcessScrollAt(1, mgl32.Vec3{0, 0, 5}, mgl32.Vec3{0, 0, -1})
+	if c.TargetFov == 45 {
+		t.Error("ProcessScrollAt with CursorZoom disabled should still narrow TargetFov like ProcessScroll")
+	}
+	if c.Position != (mgl32.Vec3{0, 0, 5}) {
+		t.Errorf("Position = %v, want unchanged while CursorZoom is disabled", c.Position)
+	}
+}
+
+func TestCameraProcessScrollAtDollysTowardCursorRay(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 5, 5}, -90, 0, 45)
+	c.CursorZoom = true
+	before := c.Position
+
+	rayDir := mgl32.Vec3{0, -1, -1}.Normalize()
+	c.ProcessScrollAt(1, c.Position, rayDir)
+
+	if c.Position == before {
+		t.Error("ProcessScrollAt with CursorZoom enabled should move Position")
+	}
+	if c.TargetFov != 45 {
+		t.Errorf("TargetFov = %v, want unchanged when CursorZoom is enabled", c.TargetFov)
+	}
+}
+
+func TestCameraProcessScrollAtMovesOrbitTargetAlongRay(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 10}, -90, 0, 45)
+	c.CursorZoom = true
+	c.ToggleOrbit()
+	before := c.OrbitTarget
+
+	c.ProcessScrollAt(1, c.Position, mgl32.Vec3{1, 0, 0})
+
+	if c.OrbitTarget == before {
+		t.Error("ProcessScrollAt in orbit mode should move OrbitTarget along rayDir")
+	}
+	if c.TargetOrbitRadius >= c.OrbitRadius {
+		t.Errorf("TargetOrbitRadius = %v, want less than starting OrbitRadius = %v after scrolling in", c.TargetOrbitRadius, c.OrbitRadius)
+	}
+}
+
+func TestCameraMoveDecaysVelocityWithoutInput(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.Move(1, 0, 0.1)
+	if c.Velocity.Len() == 0 {
+		t.Fatal("Move with forward input should have built up Velocity")
+	}
+	moving := c.Velocity.Len()
+
+	c.Move(0, 0, 0.1)
+	if c.Velocity.Len() >= moving {
+		t.Errorf("Velocity = %v after releasing input, want it to have decayed below %v", c.Velocity.Len(), moving)
+	}
+}
+
+func TestCameraProcessMovementCombinesAxesWithoutStomping(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	// Forward/strafe idle, vertical held: a naive Move-then-MoveVertical
+	// sequence would have Move's idle-axis damping fight MoveVertical's
+	// acceleration every frame.
+	for i := 0; i < 10; i++ {
+		c.ProcessMovement(0, 0, 1, 0.016)
+	}
+	if c.Position.Y() <= 0 {
+		t.Errorf("Position.Y() = %v after holding vertical input, want positive", c.Position.Y())
+	}
+}
+
+func TestCameraProcessMouseWithoutLookSmoothingIsImmediate(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.ProcessMouse(10, 0)
+	if c.Yaw != -80 {
+		t.Errorf("Yaw = %v, want -80 applied immediately", c.Yaw)
+	}
+}
+
+func TestCameraLookSmoothingEasesTowardTarget(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.LookSmoothing = true
+	c.ProcessMouse(10, 0)
+	if c.Yaw != -90 {
+		t.Errorf("Yaw = %v, want unchanged until UpdateLook eases it", c.Yaw)
+	}
+	for i := 0; i < 100; i++ {
+		c.UpdateLook()
+	}
+	if diff := c.Yaw - c.TargetYaw; diff > 0.01 || diff < -0.01 {
+		t.Errorf("Yaw = %v, want to have converged to TargetYaw = %v", c.Yaw, c.TargetYaw)
+	}
+}
+
+func TestCameraWalkModeIgnoresPitchForMovement(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, -45, 45)
+	c.WalkMode = true
+	for i := 0; i < 10; i++ {
+		c.Move(1, 0, 0.1)
+	}
+	if c.Position.Y() != 0 {
+		t.Errorf("Position.Y() = %v after moving forward while pitched down in WalkMode, want 0", c.Position.Y())
+	}
+}
+
+func TestCameraFlyModeFollowsPitchForMovement(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, -45, 45)
+	for i := 0; i < 10; i++ {
+		c.Move(1, 0, 0.1)
+	}
+	if c.Position.Y() >= 0 {
+		t.Errorf("Position.Y() = %v after moving forward while pitched down outside WalkMode, want negative", c.Position.Y())
+	}
+}
+
+func TestCameraRollTiltsUpWithoutChangingFront(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.QuaternionMode = true
+	frontBefore := c.Front
+
+	c.Roll(1, 1.0)
+
+	if diff := c.Front.Sub(frontBefore).Len(); diff > 1e-3 {
+		t.Errorf("Front changed by %v after Roll, want it to stay fixed (roll rotates about Front)", diff)
+	}
+	if c.Up == (mgl32.Vec3{0, 1, 0}) {
+		t.Error("Up unchanged after Roll, want it tilted off world-up")
+	}
+}
+
+func TestCameraRollIsNoopOutsideQuaternionMode(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	before := c.Up
+	c.Roll(1, 1.0)
+	if c.Up != before {
+		t.Errorf("Up = %v, want unchanged since QuaternionMode is off", c.Up)
+	}
+}
+
+func TestCameraQuaternionModeProcessMouseTurnsFront(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.QuaternionMode = true
+	before := c.Front
+
+	c.ProcessMouse(10, 0)
+
+	if diff := c.Front.Sub(before).Len(); diff < 1e-3 {
+		t.Error("Front did not change after ProcessMouse in QuaternionMode")
+	}
+	if diff := c.Front.Len() - 1; diff > 1e-4 || diff < -1e-4 {
+		t.Errorf("Front.Len() = %v, want a unit vector", c.Front.Len())
+	}
+}
+
+func TestCameraUpdateZoomEasesTowardsTarget(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.TargetFov = 60
+	for i := 0; i < 100; i++ {
+		c.UpdateZoom()
+	}
+	if diff := c.Fov - c.TargetFov; diff > 0.01 || diff < -0.01 {
+		t.Errorf("Fov = %v, want to have converged to TargetFov = %v", c.Fov, c.TargetFov)
+	}
+}
+
+func TestCameraStartResetSnapsInstantlyWithoutAnimateReset(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{1, 2, 3}, -90, 0, 45)
+	c.StartReset(mgl32.Vec3{5, 5, 5}, 45, 10, 60)
+	if c.Position != (mgl32.Vec3{5, 5, 5}) {
+		t.Errorf("Position = %v, want an instant snap to (5,5,5)", c.Position)
+	}
+	if c.Fov != 60 {
+		t.Errorf("Fov = %v, want an instant snap to 60", c.Fov)
+	}
+}
+
+func TestCameraStartResetWithAnimateResetEasesOverTime(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 0}, -90, 0, 45)
+	c.AnimateReset = true
+	c.ResetDuration = 1.0
+	c.StartReset(mgl32.Vec3{10, 0, 0}, -90, 0, 45)
+
+	c.UpdateResetAnimation(0.5)
+	if c.Position.X() <= 0 || c.Position.X() >= 10 {
+		t.Errorf("Position.X = %v, want strictly between 0 and 10 mid-animation", c.Position.X())
+	}
+
+	c.UpdateResetAnimation(0.5)
+	if diff := c.Position.X() - 10; diff > 0.01 || diff < -0.01 {
+		t.Errorf("Position.X = %v, want to have converged to 10 once the animation finishes", c.Position.X())
+	}
+}
+
+func TestCameraMovementInputCancelsResetAnimation(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{0, 0, 0}, -90, 0, 45)
+	c.AnimateReset = true
+	c.ResetDuration = 1.0
+	c.StartReset(mgl32.Vec3{10, 0, 0}, -90, 0, 45)
+	c.UpdateResetAnimation(0.2)
+
+	c.ProcessMovement(1, 0, 0, 0.016)
+	before := c.Position
+	c.UpdateResetAnimation(0.2)
+	if c.Position != before {
+		t.Errorf("Position changed by UpdateResetAnimation after movement input should have cancelled it: got %v, want unchanged from %v", c.Position, before)
+	}
+}
+
+func TestCameraSetPlaneLockRestrictsWalkAxesToPlane(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{1, 2, 3}, -45, 30, 45)
+	c.SetPlaneLock(PlaneLockXY)
+
+	front, right := c.walkAxes()
+	wantFront, wantRight := mgl32.Vec3{0, 1, 0}, mgl32.Vec3{1, 0, 0}
+	if front != wantFront || right != wantRight {
+		t.Errorf("walkAxes() = (%v, %v), want (%v, %v) while locked to PlaneLockXY", front, right, wantFront, wantRight)
+	}
+	if v := c.verticalAxis(); v != (mgl32.Vec3{}) {
+		t.Errorf("verticalAxis() = %v, want the zero vector while a PlaneLock is set", v)
+	}
+	if c.PlaneLock != PlaneLockXY {
+		t.Errorf("PlaneLock = %v, want PlaneLockXY", c.PlaneLock)
+	}
+}
+
+func TestCameraSetPlaneLockNoneRestoresFreeMovement(t *testing.T) {
+	c := NewCamera(mgl32.Vec3{}, -90, 0, 45)
+	c.SetPlaneLock(PlaneLockXZ)
+	c.SetPlaneLock(PlaneLockNone)
+
+	if c.PlaneLock != PlaneLockNone {
+		t.Errorf("PlaneLock = %v, want PlaneLockNone", c.PlaneLock)
+	}
+	if v := c.verticalAxis(); v != (mgl32.Vec3{0, 1, 0}) {
+		t.Errorf("verticalAxis() = %v, want world up once PlaneLock is cleared", v)
+	}
+}
+
+func TestSnapToAxisViewSetsExpectedPose(t *testing.T) {
+	cases := []struct {
+		view      AxisView
+		wantPos   mgl32.Vec3
+		wantYaw   float32
+		wantPitch float32
+	}{
+		{AxisViewFront, mgl32.Vec3{0, 0, axisSnapDistance}, -90, 0},
+		{AxisViewBack, mgl32.Vec3{0, 0, -axisSnapDistance}, 90, 0},
+		{AxisViewRight, mgl32.Vec3{axisSnapDistance, 0, 0}, 180, 0},
+		{AxisViewLeft, mgl32.Vec3{-axisSnapDistance, 0, 0}, 0, 0},
+		{AxisViewTop, mgl32.Vec3{0, axisSnapDistance, 0}, -90, -89.9},
+		{AxisViewBottom, mgl32.Vec3{0, -axisSnapDistance, 0}, -90, 89.9},
+	}
+	for _, tc := range cases {
+		c := NewCamera(mgl32.Vec3{1, 1, 1}, 12, 34, 45)
+		snapToAxisView(c, tc.view)
+
+		if c.Position != tc.wantPos {
+			t.Errorf("%v: Position = %v, want %v", tc.view, c.Position, tc.wantPos)
+		}
+		if c.Yaw != tc.wantYaw {
+			t.Errorf("%v: Yaw = %v, want %v", tc.view, c.Yaw, tc.wantYaw)
+		}
+		if c.Pitch != tc.wantPitch {
+			t.Errorf("%v: Pitch = %v, want %v", tc.view, c.Pitch, tc.wantPitch)
+		}
+		if c.Fov != 45 {
+			t.Errorf("%v: Fov = %v, want unchanged at 45", tc.view, c.Fov)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// cameraInputActive is true while the viewer is collecting a typed
+// "x,y,z,yaw,pitch" coordinate via charCallback, for jumping to an exact
+// viewpoint - e.g. to reproduce a screenshot from a bug report without
+// hunting for the spot by eye. F8 starts entry (see startCameraInput);
+// Enter commits it (commitCameraInput) and Escape cancels it
+// (cancelCameraInput), both handled in keyCallback. -camera offers the
+// same "x,y,z,yaw,pitch" format as a startup flag instead.
+var cameraInputActive bool
+
+// cameraInputBuffer accumulates the characters typed during camera
+// coordinate entry; see cameraInputActive. Shown in the HUD by hudLines.
+var cameraInputBuffer string
+
+// startCameraInput begins coordinate entry, clearing any previous buffer
+// and making sure the HUD is visible so the typed text is readable.
+func startCameraInput() {
+	cameraInputActive = true
+	cameraInputBuffer = ""
+	hudVisible = true
+	log.Println("camera coordinate entry: type x,y,z,yaw,pitch then Enter (Esc to cancel)")
+}
+
+// cancelCameraInput discards the in-progress buffer without moving the
+// camera.
+func cancelCameraInput() {
+	cameraInputActive = false
+	cameraInputBuffer = ""
+}
+
+// commitCameraInput parses cameraInputBuffer and moves c there via
+// Reset, then ends entry mode regardless of whether parsing succeeded -
+// a malformed entry should drop back to normal controls, not leave the
+// viewer stuck capturing keystrokes.
+func commitCameraInput(c *Camera) {
+	defer cancelCameraInput()
+	position, yaw, pitch, err := parseCameraCoords(cameraInputBuffer)
+	if err != nil {
+		log.Printf("camera coordinate entry: %v", err)
+		return
+	}
+	c.Reset(position, yaw, pitch, c.Fov)
+	log.Printf("camera moved to %.2f %.2f %.2f, yaw %.1f, pitch %.1f", position.X(), position.Y(), position.Z(), yaw, pitch)
+}
+
+// charCallback appends typed characters to cameraInputBuffer or
+// consoleBuffer (console.go), whichever text-entry mode is active; a
+// no-op the rest of the time. The backtick that opens the console (see
+// keyCallback) also fires this callback as a printable character, so
+// it's dropped here rather than appearing as the first character typed.
+func charCallback(w *glfw.Window, char rune) {
+	switch {
+	case cameraInputActive:
+		cameraInputBuffer += string(char)
+	case consoleActive:
+		if char == '`' {
+			return
+		}
+		consoleBuffer += string(char)
+	}
+}
+
+// parseCameraCoords parses "x,y,z,yaw,pitch" - the format read by both
+// the F8 entry mode and the -camera flag - into a position and
+// orientation.
+func parseCameraCoords(s string) (position mgl32.Vec3, yaw, pitch float32, err error) {
+	parts := strings.Split(strings.TrimSpace(s), ",")
+	if len(parts) != 5 {
+		return mgl32.Vec3{}, 0, 0, fmt.Errorf("want 5 comma-separated values (x,y,z,yaw,pitch), got %d", len(parts))
+	}
+	var values [5]float64
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return mgl32.Vec3{}, 0, 0, fmt.Errorf("value %d (%q): %w", i, p, err)
+		}
+		values[i] = v
+	}
+	position = mgl32.Vec3{float32(values[0]), float32(values[1]), float32(values[2])}
+	yaw = float32(values[3])
+	pitch = float32(values[4])
+	return position, yaw, pitch, nil
+}
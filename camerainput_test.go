@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestParseCameraCoordsValid(t *testing.T) {
+	position, yaw, pitch, err := parseCameraCoords("1,2,3,45,-10")
+	if err != nil {
+		t.Fatalf("parseCameraCoords: %v", err)
+	}
+	if position.X() != 1 || position.Y() != 2 || position.Z() != 3 {
+		t.Errorf("position = %v, want {1 2 3}", position)
+	}
+	if yaw != 45 || pitch != -10 {
+		t.Errorf("yaw/pitch = %v/%v, want 45/-10", yaw, pitch)
+	}
+}
+
+func TestParseCameraCoordsRejectsWrongFieldCount(t *testing.T) {
+	if _, _, _, err := parseCameraCoords("1,2,3"); err == nil {
+		t.Error("parseCameraCoords with 3 fields, want error")
+	}
+}
+
+func TestParseCameraCoordsRejectsNonNumeric(t *testing.T) {
+	if _, _, _, err := parseCameraCoords("1,2,oops,45,-10"); err == nil {
+		t.Error("parseCameraCoords with non-numeric field, want error")
+	}
+}
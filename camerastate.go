@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// defaultCameraStatePath is where saveCameraState/loadCameraState read
+// and write by default, so closing and reopening the viewer returns to
+// the last saved vantage point without needing a flag.
+const defaultCameraStatePath = "camera_state.json"
+
+// cameraStateJSON is the on-disk shape of a saved camera pose.
+type cameraStateJSON struct {
+	Position    [3]float32 `json:"position"`
+	Yaw         float32    `json:"yaw"`
+	Pitch       float32    `json:"pitch"`
+	Fov         float32    `json:"fov"`
+	Sensitivity float32    `json:"sensitivity"`
+	InvertY     bool       `json:"invertY"`
+}
+
+// saveCameraState writes c's position, yaw, pitch, fov, and mouse-look
+// settings to path as JSON.
+func saveCameraState(c *Camera, path string) error {
+	state := cameraStateJSON{
+		Position:    [3]float32{c.Position.X(), c.Position.Y(), c.Position.Z()},
+		Yaw:         c.Yaw,
+		Pitch:       c.Pitch,
+		Fov:         c.Fov,
+		Sensitivity: c.Sensitivity,
+		InvertY:     c.InvertY,
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal camera state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write camera state: %w", err)
+	}
+	return nil
+}
+
+// loadCameraState reads a camera pose saved by saveCameraState from path
+// and applies it to c via Reset.
+func loadCameraState(c *Camera, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read camera state: %w", err)
+	}
+	var state cameraStateJSON
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("parse camera state: %w", err)
+	}
+	position := mgl32.Vec3{state.Position[0], state.Position[1], state.Position[2]}
+	c.Reset(position, state.Yaw, state.Pitch, state.Fov)
+	// Sensitivity is omitted as 0 by a state file saved before this field
+	// existed; treat that as "not present" rather than actually zeroing
+	// out mouse look.
+	if state.Sensitivity != 0 {
+		c.Sensitivity = state.Sensitivity
+	}
+	c.InvertY = state.InvertY
+	return nil
+}
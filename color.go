@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+)
+
+// parseHexColor parses a "#RRGGBB" string into normalized [0,1] floats.
+func parseHexColor(s string) (r, g, b float32, err error) {
+	if len(s) != 7 || s[0] != '#' {
+		return 0, 0, 0, fmt.Errorf("color %q: want format #RRGGBB", s)
+	}
+	var ri, gi, bi int
+	if _, err := fmt.Sscanf(s[1:], "%02x%02x%02x", &ri, &gi, &bi); err != nil {
+		return 0, 0, 0, fmt.Errorf("color %q: %w", s, err)
+	}
+	return float32(ri) / 255, float32(gi) / 255, float32(bi) / 255, nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// consoleActive is true while the text console (backtick to toggle) is
+// capturing typed characters via charCallback instead of letting them
+// through to the rest of the app - the console's equivalent of
+// cameraInputActive (see camerainput.go). Only one of the two can be
+// active at a time; see keyCallback.
+var consoleActive bool
+
+// consoleBuffer accumulates the characters typed into the open console;
+// see consoleActive. Shown in the HUD by hudLines, alongside
+// consoleHistory.
+var consoleBuffer string
+
+// consoleHistory holds the console's most recent command lines and their
+// results, oldest first, so a typed command's effect (or error) stays
+// visible for a moment after Enter rather than vanishing immediately.
+var consoleHistory []string
+
+// consoleHistoryLimit caps consoleHistory so a long session doesn't grow
+// the HUD without bound.
+const consoleHistoryLimit = 6
+
+// toggleConsole opens or closes the console, clearing any previous
+// buffer so a stale half-typed command doesn't reappear next time it's
+// opened.
+func toggleConsole() {
+	consoleActive = !consoleActive
+	consoleBuffer = ""
+	if consoleActive {
+		hudVisible = true
+	}
+}
+
+// consoleEcho appends a line to consoleHistory, dropping the oldest once
+// consoleHistoryLimit is exceeded.
+func consoleEcho(line string) {
+	consoleHistory = append(consoleHistory, line)
+	if len(consoleHistory) > consoleHistoryLimit {
+		consoleHistory = consoleHistory[len(consoleHistory)-consoleHistoryLimit:]
+	}
+}
+
+// commitConsoleCommand parses consoleBuffer as "<command> [args...]",
+// runs it against consoleCommands, and echoes both the typed line and
+// its result (or error) to consoleHistory. The console stays open
+// afterward, so a sequence of tweaks doesn't need reopening it each
+// time; Escape or backtick closes it.
+func commitConsoleCommand() {
+	line := strings.TrimSpace(consoleBuffer)
+	consoleBuffer = ""
+	if line == "" {
+		return
+	}
+	consoleEcho(fmt.Sprintf("> %s", line))
+
+	fields := strings.Fields(line)
+	cmd, args := fields[0], fields[1:]
+	handler, ok := consoleCommands[cmd]
+	if !ok {
+		consoleEcho(fmt.Sprintf("  unknown command %q", cmd))
+		log.Printf("console: unknown command %q", cmd)
+		return
+	}
+	result, err := handler(args)
+	if err != nil {
+		consoleEcho(fmt.Sprintf("  error: %v", err))
+		log.Printf("console: %s: %v", cmd, err)
+		return
+	}
+	consoleEcho(fmt.Sprintf("  %s", result))
+	log.Printf("console: %s", result)
+}
+
+// consoleCommands maps a command's first word to its handler. Each
+// handler receives the remaining words as args and returns either a
+// short result string to echo, or an error describing what's wrong with
+// the input.
+var consoleCommands = map[string]func(args []string) (string, error){
+	"set":   consoleCmdSet,
+	"bg":    consoleCmdBG,
+	"grid":  consoleCmdGrid,
+	"reset": consoleCmdReset,
+}
+
+// consoleSetters maps a "set" command's parameter name to a handler
+// taking the raw value string; see consoleCmdSet.
+var consoleSetters = map[string]func(value string) (string, error){
+	"speed": consoleSetSpeed,
+	"fov":   consoleSetFov,
+}
+
+// consoleCmdSet implements "set <param> <value>", e.g. "set speed 5" or
+// "set fov 60".
+func consoleCmdSet(args []string) (string, error) {
+	if len(args) != 2 {
+		return "", fmt.Errorf("usage: set <param> <value>")
+	}
+	setter, ok := consoleSetters[args[0]]
+	if !ok {
+		return "", fmt.Errorf("unknown parameter %q", args[0])
+	}
+	return setter(args[1])
+}
+
+func consoleSetSpeed(value string) (string, error) {
+	v, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return "", err
+	}
+	camera.Speed = clamp32(float32(v), minCameraSpeed, maxCameraSpeed)
+	return fmt.Sprintf("speed = %.2f", camera.Speed), nil
+}
+
+func consoleSetFov(value string) (string, error) {
+	v, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return "", err
+	}
+	camera.Fov = clamp32(float32(v), fovMin, fovMax)
+	camera.TargetFov = camera.Fov
+	return fmt.Sprintf("fov = %.1f", camera.Fov), nil
+}
+
+// consoleCmdBG implements "bg <#rrggbb>", reusing the same parser as the
+// -bg flag.
+func consoleCmdBG(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("usage: bg <#rrggbb>")
+	}
+	r, g, b, err := parseHexColor(args[0])
+	if err != nil {
+		return "", err
+	}
+	clearColor = [3]float32{r, g, b}
+	gl.ClearColor(r, g, b, 1.0)
+	return fmt.Sprintf("bg = %s", args[0]), nil
+}
+
+// consoleCmdGrid implements "grid <spacing>", rebuilding grids from
+// DefaultGridConfigs with every configured grid's spacing scaled by the
+// same ratio, so the fine/coarse grid relationship is preserved instead
+// of just replacing the finest grid alone.
+func consoleCmdGrid(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("usage: grid <spacing>")
+	}
+	spacing, err := strconv.ParseFloat(args[0], 32)
+	if err != nil {
+		return "", err
+	}
+	if spacing <= 0 {
+		return "", fmt.Errorf("spacing must be positive")
+	}
+
+	ratio := float32(spacing) / DefaultGridConfigs[0].Spacing
+	configs := make([]GridConfig, len(DefaultGridConfigs))
+	for i, cfg := range DefaultGridConfigs {
+		cfg.Spacing *= ratio
+		configs[i] = cfg
+	}
+	for _, g := range grids {
+		g.Delete()
+	}
+	grids = NewGridSet(configs)
+	return fmt.Sprintf("grid spacing = %.3g (every configured grid scaled by the same ratio)", spacing), nil
+}
+
+// consoleCmdReset implements "reset", the console's equivalent of
+// keyBindings.Reset.
+func consoleCmdReset(args []string) (string, error) {
+	if len(args) != 0 {
+		return "", fmt.Errorf("usage: reset")
+	}
+	camera.StartReset(homePos, homeYaw, homePitch, homeFov)
+	return "camera reset", nil
+}
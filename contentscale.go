@@ -0,0 +1,31 @@
+package main
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// contentScaleX/contentScaleY are the window's current DPI content
+// scale (1.0 on a standard-density display, e.g. 2.0 on a typical
+// HiDPI/Retina display), queried once in Init and kept in sync by
+// contentScaleCallback. setLineWidth and the fixed-pixel screen-space
+// overlays (HUD, Crosshair) read these through uiScale so lines and UI
+// elements keep roughly constant physical size across displays, instead
+// of looking quarter-size on HiDPI - the framebuffer there has the same
+// number of logical pixels but up to 4x the actual raster pixels, and
+// every size in this package is otherwise expressed in framebuffer
+// pixels (see fbWidth/fbHeight).
+var contentScaleX, contentScaleY float32 = 1, 1
+
+// uiScale averages contentScaleX/Y into the single multiplier
+// setLineWidth and the screen-space overlays use; the two are equal on
+// every display this was tested against, but averaging rather than
+// picking one axis is the safer default if a driver ever reports them
+// unequal.
+func uiScale() float32 {
+	return (contentScaleX + contentScaleY) / 2
+}
+
+// contentScaleCallback keeps contentScaleX/Y in sync when the window
+// moves to a display with a different DPI scale (e.g. dragged from a
+// standard monitor to a Retina one) without requiring a restart.
+func contentScaleCallback(w *glfw.Window, x, y float32) {
+	contentScaleX, contentScaleY = x, y
+}
@@ -0,0 +1,80 @@
+package main
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// Crosshair draws a small "+" reticle at screen center as a
+// screen-space overlay, the same pixel-to-NDC technique Plot2D uses -
+// in fact it reuses Plot2D's shader outright, since both just need a
+// resolution uniform and a flat color for pixel-space line vertices.
+// Shown in first-person fly mode (see crosshairVisible/camera.WalkMode
+// in main.go) so there's some indication of where "forward" points
+// before lining up a pick or screenshot.
+type Crosshair struct {
+	program           uint32
+	vao, vbo          uint32
+	resolutionUniform int32
+	colorUniform      int32
+}
+
+// NewCrosshair compiles the overlay's shader and allocates its vertex
+// buffer; Draw uploads fresh line-segment endpoints every call since
+// the window can be resized at any time.
+func NewCrosshair() *Crosshair {
+	program, err := newProgram(plot2DVertexShader, plot2DFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	var vao, vbo uint32
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, 2*4, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.BindVertexArray(0)
+
+	return &Crosshair{
+		program:           program,
+		vao:               vao,
+		vbo:               vbo,
+		resolutionUniform: gl.GetUniformLocation(program, gl.Str("resolution\x00")),
+		colorUniform:      gl.GetUniformLocation(program, gl.Str("color\x00")),
+	}
+}
+
+// Draw renders a "+" of four size-pixel-long arms centered on the
+// screen, with depth testing disabled so it always sits on top of the
+// scene, same as HUD/Plot2D.
+func (c *Crosshair) Draw(screenW, screenH int, size float32, color [3]float32) {
+	cx, cy := float32(screenW)/2, float32(screenH)/2
+	vertices := []float32{
+		cx - size, cy, cx + size, cy,
+		cx, cy - size, cx, cy + size,
+	}
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, c.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, safeGLPtr(vertices), gl.DYNAMIC_DRAW)
+
+	gl.UseProgram(c.program)
+	gl.Uniform2f(c.resolutionUniform, float32(screenW), float32(screenH))
+	gl.Uniform3f(c.colorUniform, color[0], color[1], color[2])
+
+	gl.Disable(gl.DEPTH_TEST)
+	gl.BindVertexArray(c.vao)
+	gl.DrawArrays(gl.LINES, 0, 4)
+	gl.BindVertexArray(0)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+// Delete frees the overlay's GL resources.
+func (c *Crosshair) Delete() {
+	gl.DeleteVertexArrays(1, &c.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &c.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(c.program)
+	trackDelete("program")
+}
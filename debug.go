@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// debugFlag enables the GL_DEBUG_OUTPUT callback and checkGLError's
+// gl.GetError fallback. Off by default: the callback has a real (if
+// small) per-draw-call cost, and most runs don't need it.
+var debugFlag = flag.Bool("debug", false, "enable OpenGL debug output and error checking")
+
+// enableGLDebug registers debugMessageCallback as the driver's debug
+// output sink and turns GL_DEBUG_OUTPUT on. Must be called after gl.Init,
+// and is a no-op unless -debug is set.
+func enableGLDebug() {
+	if !*debugFlag {
+		return
+	}
+	gl.Enable(gl.DEBUG_OUTPUT)
+	gl.DebugMessageCallback(debugMessageCallback, nil)
+	log.Println("GL debug output enabled")
+}
+
+// debugMessageCallback logs every GL_DEBUG_OUTPUT message as it arrives,
+// with enough context (source, severity, message) to point at what
+// triggered it without needing checkGLError calls sprinkled everywhere.
+func debugMessageCallback(source, gltype, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+	log.Printf("gl debug: source=%s type=%s severity=%s: %s", glDebugSourceString(source), glDebugTypeString(gltype), glDebugSeverityString(severity), message)
+}
+
+// checkGLError queries gl.GetError and logs any error found, tagged with
+// the caller-supplied description of what was just attempted. It's the
+// fallback for drivers (or contexts) where DebugMessageCallback isn't
+// available, and is only worth the call overhead when -debug is set.
+func checkGLError(tag string) {
+	if !*debugFlag {
+		return
+	}
+	for {
+		err := gl.GetError()
+		if err == gl.NO_ERROR {
+			return
+		}
+		log.Printf("gl error after %s: 0x%x", tag, err)
+	}
+}
+
+func glDebugSourceString(source uint32) string {
+	switch source {
+	case gl.DEBUG_SOURCE_API:
+		return "api"
+	case gl.DEBUG_SOURCE_WINDOW_SYSTEM:
+		return "window_system"
+	case gl.DEBUG_SOURCE_SHADER_COMPILER:
+		return "shader_compiler"
+	case gl.DEBUG_SOURCE_THIRD_PARTY:
+		return "third_party"
+	case gl.DEBUG_SOURCE_APPLICATION:
+		return "application"
+	default:
+		return "other"
+	}
+}
+
+func glDebugTypeString(gltype uint32) string {
+	switch gltype {
+	case gl.DEBUG_TYPE_ERROR:
+		return "error"
+	case gl.DEBUG_TYPE_DEPRECATED_BEHAVIOR:
+		return "deprecated"
+	case gl.DEBUG_TYPE_UNDEFINED_BEHAVIOR:
+		return "undefined_behavior"
+	case gl.DEBUG_TYPE_PORTABILITY:
+		return "portability"
+	case gl.DEBUG_TYPE_PERFORMANCE:
+		return "performance"
+	default:
+		return "other"
+	}
+}
+
+func glDebugSeverityString(severity uint32) string {
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		return "high"
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		return "medium"
+	case gl.DEBUG_SEVERITY_LOW:
+		return "low"
+	case gl.DEBUG_SEVERITY_NOTIFICATION:
+		return "notification"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"log"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// frameAll repositions the camera backward or forward along its current
+// Front, without changing yaw/pitch/fov, so the bounding sphere of
+// every visible bounded object fits within the current fov - like
+// Blender's "view selected" (Numpad "."), except there's no selection
+// model here, so it frames everything visible at once. Bound to
+// KeyPeriod; essential once an OBJ model of unknown scale (-model) can
+// be loaded, since its size can't be guessed from the camera's starting
+// pose.
+func frameAll(c *Camera) {
+	box, ok := visibleBounds()
+	if !ok {
+		log.Println("frame all: nothing visible to frame")
+		return
+	}
+
+	center := box.Min.Add(box.Max).Mul(0.5)
+	radius := box.Max.Sub(box.Min).Len() / 2
+	if radius < 0.01 {
+		radius = 0.01
+	}
+
+	halfFov := mgl32.DegToRad(c.Fov) / 2
+	distance := radius / tan32(halfFov)
+
+	c.StartReset(center.Sub(c.Front.Mul(distance)), c.Yaw, c.Pitch, c.Fov)
+}
+
+// visibleBounds merges the world-space AABBs of every currently visible
+// bounded scene object - the loaded -model and the demo cube. The grid
+// and axes have no meaningful bounds to frame, so they're not part of
+// this. Reports false if nothing bounded is currently visible.
+func visibleBounds() (AABB, bool) {
+	var box AABB
+	has := false
+
+	merge := func(b AABB) {
+		if !has {
+			box = b
+			has = true
+			return
+		}
+		box = mergeAABB(box, b)
+	}
+
+	if model != nil {
+		merge(model.Bounds())
+	}
+	if sceneObjects.Cube && demoCube != nil {
+		merge(demoCube.worldBounds())
+	}
+
+	return box, has
+}
+
+// mergeAABB returns the smallest AABB containing both a and b.
+func mergeAABB(a, b AABB) AABB {
+	return AABB{
+		Min: mgl32.Vec3{minf(a.Min.X(), b.Min.X()), minf(a.Min.Y(), b.Min.Y()), minf(a.Min.Z(), b.Min.Z())},
+		Max: mgl32.Vec3{maxf(a.Max.X(), b.Max.X()), maxf(a.Max.Y(), b.Max.Y()), maxf(a.Max.Z(), b.Max.Z())},
+	}
+}
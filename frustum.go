@@ -0,0 +1,116 @@
+package main
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Plane is a half-space boundary Ax+By+Cz+D=0, with Normal=(A,B,C)
+// normalized so SignedDistance reads directly in world units.
+type Plane struct {
+	Normal mgl32.Vec3
+	D      float32
+}
+
+// SignedDistance is positive on the side Normal points toward, zero on
+// the plane, negative on the other side.
+func (p Plane) SignedDistance(point mgl32.Vec3) float32 {
+	return p.Normal.Dot(point) + p.D
+}
+
+// Frustum is the six-plane view volume of a projection*view matrix,
+// used to cull meshes that can't possibly be visible this frame.
+type Frustum struct {
+	planes [6]Plane
+}
+
+// NewFrustum extracts the frustum's six planes from a combined
+// projection*view matrix via the standard Gribb/Hartmann method.
+func NewFrustum(combined mgl32.Mat4) Frustum {
+	r0, r1, r2, r3 := combined.Row(0), combined.Row(1), combined.Row(2), combined.Row(3)
+	raw := [6]mgl32.Vec4{
+		r3.Add(r0), // left
+		r3.Sub(r0), // right
+		r3.Add(r1), // bottom
+		r3.Sub(r1), // top
+		r3.Add(r2), // near
+		r3.Sub(r2), // far
+	}
+
+	var f Frustum
+	for i, p := range raw {
+		normal := mgl32.Vec3{p[0], p[1], p[2]}
+		length := normal.Len()
+		if length == 0 {
+			length = 1
+		}
+		f.planes[i] = Plane{Normal: normal.Mul(1 / length), D: p[3] / length}
+	}
+	return f
+}
+
+// Intersects reports whether aabb, transformed by model, is at least
+// partially inside the frustum. It tests the box's 8 corners against
+// each plane and only rejects a box once every corner is outside the
+// same plane, so boxes that merely straddle a plane still pass.
+func (f Frustum) Intersects(aabb AABB, model mgl32.Mat4) bool {
+	corners := aabb.corners()
+	for i := range corners {
+		corners[i] = mgl32.TransformCoordinate(corners[i], model)
+	}
+
+	for _, plane := range f.planes {
+		allOutside := true
+		for _, c := range corners {
+			if plane.SignedDistance(c) >= 0 {
+				allOutside = false
+				break
+			}
+		}
+		if allOutside {
+			return false
+		}
+	}
+	return true
+}
+
+// AABB is an axis-aligned bounding box, typically a mesh's bounds in
+// its own local space.
+type AABB struct {
+	Min, Max mgl32.Vec3
+}
+
+// NewAABB computes the bounding box of the position component of
+// interleaved vertex data, where each vertex occupies stride floats
+// and position is the first 3 of them.
+func NewAABB(vertices []float32, stride int) AABB {
+	if len(vertices) < 3 {
+		return AABB{}
+	}
+	min := mgl32.Vec3{vertices[0], vertices[1], vertices[2]}
+	max := min
+	for i := 0; i+2 < len(vertices); i += stride {
+		p := mgl32.Vec3{vertices[i], vertices[i+1], vertices[i+2]}
+		for axis := 0; axis < 3; axis++ {
+			if p[axis] < min[axis] {
+				min[axis] = p[axis]
+			}
+			if p[axis] > max[axis] {
+				max[axis] = p[axis]
+			}
+		}
+	}
+	return AABB{Min: min, Max: max}
+}
+
+func (b AABB) corners() [8]mgl32.Vec3 {
+	return [8]mgl32.Vec3{
+		{b.Min.X(), b.Min.Y(), b.Min.Z()},
+		{b.Max.X(), b.Min.Y(), b.Min.Z()},
+		{b.Min.X(), b.Max.Y(), b.Min.Z()},
+		{b.Max.X(), b.Max.Y(), b.Min.Z()},
+		{b.Min.X(), b.Min.Y(), b.Max.Z()},
+		{b.Max.X(), b.Min.Y(), b.Max.Z()},
+		{b.Min.X(), b.Max.Y(), b.Max.Z()},
+		{b.Max.X(), b.Max.Y(), b.Max.Z()},
+	}
+}
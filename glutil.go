@@ -0,0 +1,23 @@
+package main
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// safeGLPtr wraps gl.Ptr, returning nil for empty/nil slices instead of
+// letting gl.Ptr index element 0 of a zero-length slice. Non-slice
+// arguments (e.g. a single float32 for glUniform calls) pass through
+// unchanged.
+func safeGLPtr(data interface{}) unsafe.Pointer {
+	if data == nil {
+		return nil
+	}
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice && v.Len() == 0 {
+		return nil
+	}
+	return gl.Ptr(data)
+}
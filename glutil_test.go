@@ -0,0 +1,15 @@
+package main
+
+import "testing"
+
+func TestSafeGLPtrEmptySlice(t *testing.T) {
+	if ptr := safeGLPtr([]float32{}); ptr != nil {
+		t.Errorf("safeGLPtr(empty) = %v, want nil", ptr)
+	}
+	if ptr := safeGLPtr([]float32(nil)); ptr != nil {
+		t.Errorf("safeGLPtr(nil) = %v, want nil", ptr)
+	}
+	if ptr := safeGLPtr([]float32{1, 2, 3}); ptr == nil {
+		t.Errorf("safeGLPtr(non-empty) = nil, want non-nil")
+	}
+}
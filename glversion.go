@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// glVersionCandidates are the GL context versions createWindowWithFallback
+// tries, most capable first: 4.6 (what every shader here targets), then
+// 4.1 (macOS's longstanding core-profile ceiling - Apple never shipped
+// anything newer), then 3.3 (the floor the geometry-shader thick-line
+// technique and this package's UBOs need).
+var glVersionCandidates = [][2]int{{4, 6}, {4, 1}, {3, 3}}
+
+// glVersionMajor/glVersionMinor record the context version
+// createWindowWithFallback actually negotiated, defaulting to the
+// preferred 4.6 until Init runs. compileShader uses these to rewrite
+// each embedded shader's #version directive to match.
+var glVersionMajor, glVersionMinor = glVersionCandidates[0][0], glVersionCandidates[0][1]
+
+// glslBindingQualifierMinVersion is the GLSL version (major*100+minor*10)
+// that `layout(..., binding = N)` needs (GL_ARB_shading_language_420pack,
+// folded into core at 4.2). Below it, compileShader strips the
+// qualifier from shader source and newProgram/newGeometryProgram bind
+// the block explicitly at link time instead; see bindSharedUBOs.
+const glslBindingQualifierMinVersion = 420
+
+// glslVersion returns the negotiated context's GLSL version number, e.g.
+// 460, 410, 330 - the form #version directives and this package's own
+// version comparisons use.
+func glslVersion() int {
+	return glVersionMajor*100 + glVersionMinor*10
+}
+
+// createWindowWithFallback tries each of glVersionCandidates in turn,
+// returning the first context GLFW can create. Drivers generally grant
+// exactly what's requested or fail outright rather than downgrading
+// themselves, so retrying with explicit lower hints is the only way to
+// run on a 4.1-ceiling driver instead of panicking on the first failed
+// CreateWindow.
+func createWindowWithFallback(width, height int, title string) (*glfw.Window, error) {
+	var lastErr error
+	for _, v := range glVersionCandidates {
+		glfw.WindowHint(glfw.ContextVersionMajor, v[0])
+		glfw.WindowHint(glfw.ContextVersionMinor, v[1])
+		glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+		glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+
+		window, err := glfw.CreateWindow(width, height, title, nil, nil)
+		if err == nil {
+			glVersionMajor, glVersionMinor = v[0], v[1]
+			if v != glVersionCandidates[0] {
+				log.Printf("OpenGL %d.%d core unavailable; running on %d.%d core instead (some geometry-shader effects may be degraded)",
+					glVersionCandidates[0][0], glVersionCandidates[0][1], v[0], v[1])
+			}
+			return window, nil
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("no supported OpenGL context available (tried 4.6, 4.1, 3.3 core profile); this program requires at least OpenGL 3.3 core profile with geometry shader support - update your graphics driver, or on macOS note Apple's core-profile ceiling is 4.1: %w", lastErr)
+}
+
+// versionDirectiveRE matches the leading "#version N core" directive
+// every embedded shader in this package starts with.
+var versionDirectiveRE = regexp.MustCompile(`#version \d+ core`)
+
+// bindingQualifierRE matches a `, binding = N` layout qualifier clause,
+// stripped out below glslBindingQualifierMinVersion; see
+// rewriteShaderVersion.
+var bindingQualifierRE = regexp.MustCompile(`,\s*binding\s*=\s*\d+`)
+
+// rewriteShaderVersion retargets source's #version directive to the
+// negotiated context version, and - below glslBindingQualifierMinVersion
+// - strips `binding = N` layout qualifiers the driver's GLSL can't
+// parse, since explicit UBO binding syntax needs GLSL 420+.
+func rewriteShaderVersion(source string) string {
+	source = versionDirectiveRE.ReplaceAllString(source, fmt.Sprintf("#version %d core", glslVersion()))
+	if glslVersion() < glslBindingQualifierMinVersion {
+		source = bindingQualifierRE.ReplaceAllString(source, "")
+	}
+	return source
+}
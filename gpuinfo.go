@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// gpuInfoFlag/gpuInfoOutFlag print the GPU/driver's GL capabilities as
+// JSON for attaching to bug reports, since "what GPU/driver/GL version
+// is this" is one of the first questions any rendering bug triage
+// needs answered. Read-only: it only queries gl.GetString/GetIntegerv,
+// so it's safe to run on any machine that can open a window at all.
+var (
+	gpuInfoFlag    = flag.Bool("gpuinfo", false, "print startup GL capabilities as JSON, for attaching to bug reports")
+	gpuInfoOutFlag = flag.String("gpuinfo-out", "", "write -gpuinfo's JSON to this path instead of stdout")
+)
+
+// gpuInfoJSON is the on-disk/stdout shape printed by logGPUInfo.
+type gpuInfoJSON struct {
+	Renderer       string   `json:"renderer"`
+	Vendor         string   `json:"vendor"`
+	GLVersion      string   `json:"glVersion"`
+	GLSLVersion    string   `json:"glslVersion"`
+	MaxTextureSize int32    `json:"maxTextureSize"`
+	MaxSamples     int32    `json:"maxSamples"`
+	Extensions     []string `json:"extensions"`
+}
+
+// collectGPUInfo gathers the current GL context's capabilities. Must be
+// called after gl.Init().
+func collectGPUInfo() gpuInfoJSON {
+	var maxTextureSize, maxSamples, numExtensions int32
+	gl.GetIntegerv(gl.MAX_TEXTURE_SIZE, &maxTextureSize)
+	gl.GetIntegerv(gl.MAX_SAMPLES, &maxSamples)
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &numExtensions)
+
+	extensions := make([]string, numExtensions)
+	for i := int32(0); i < numExtensions; i++ {
+		extensions[i] = gl.GoStr(gl.GetStringi(gl.EXTENSIONS, uint32(i)))
+	}
+
+	return gpuInfoJSON{
+		Renderer:       gl.GoStr(gl.GetString(gl.RENDERER)),
+		Vendor:         gl.GoStr(gl.GetString(gl.VENDOR)),
+		GLVersion:      gl.GoStr(gl.GetString(gl.VERSION)),
+		GLSLVersion:    gl.GoStr(gl.GetString(gl.SHADING_LANGUAGE_VERSION)),
+		MaxTextureSize: maxTextureSize,
+		MaxSamples:     maxSamples,
+		Extensions:     extensions,
+	}
+}
+
+// logGPUInfo writes collectGPUInfo's result as indented JSON to
+// gpuInfoOutFlag's path, or stdout if it's unset.
+func logGPUInfo() error {
+	data, err := json.MarshalIndent(collectGPUInfo(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal gpu info: %w", err)
+	}
+	data = append(data, '\n')
+
+	if *gpuInfoOutFlag == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*gpuInfoOutFlag, data, 0644); err != nil {
+		return fmt.Errorf("write gpu info: %w", err)
+	}
+	log.Printf("wrote GL capabilities to %s", *gpuInfoOutFlag)
+	return nil
+}
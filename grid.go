@@ -0,0 +1,232 @@
+package main
+
+import (
+	"log"
+	"math"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// maxGridLines caps GridConfig.Lines so a misconfigured or user-supplied
+// grid can't balloon into a multi-million-vertex buffer.
+const maxGridLines = 2000
+
+// GridConfig describes one ground-plane grid: its line spacing, how many
+// lines to draw on each side of the origin, and its render color/alpha.
+// A scene can load several of these at different scales at once (e.g. a
+// 1-unit fine grid near the origin and a 100-unit coarse grid for the
+// broader scene).
+type GridConfig struct {
+	Spacing float32
+	Lines   int
+	Color   mgl32.Vec3
+	Alpha   float32
+
+	// MajorInterval, if > 0, draws every MajorInterval-th line (e.g.
+	// every 5th) in MajorColor at MajorThickness instead of Color at the
+	// default line width, so scale is readable at a glance. 0 disables
+	// major-line distinction; the grid is drawn uniformly in Color.
+	MajorInterval  int
+	MajorColor     mgl32.Vec3
+	MajorThickness float32
+}
+
+// defaultCenterThickness is the GL line width used for the grid's X and
+// Z center lines (through the origin), which always draw in their
+// matching axis color (X red, Z blue; see Axes) rather than Color/
+// MajorColor, regardless of MajorInterval, so the grid's own origin
+// lines up with the axes' origin at a glance.
+const defaultCenterThickness = float32(2.0)
+
+// DefaultGridConfigs is the out-of-the-box grid set: a fine grid for
+// close-up work plus a coarse grid for scene-scale orientation, each
+// with every 5th line drawn as a major line.
+var DefaultGridConfigs = []GridConfig{
+	{
+		Spacing: 1, Lines: 20, Color: mgl32.Vec3{0.35, 0.35, 0.4}, Alpha: 1.0,
+		MajorInterval: 5, MajorColor: mgl32.Vec3{0.55, 0.55, 0.6}, MajorThickness: 2.0,
+	},
+	{
+		Spacing: 100, Lines: 10, Color: mgl32.Vec3{0.5, 0.5, 0.55}, Alpha: 0.35,
+		MajorInterval: 5, MajorColor: mgl32.Vec3{0.7, 0.7, 0.75}, MajorThickness: 2.0,
+	},
+}
+
+// Grid is a single renderable set of ground-plane lines. Its vertex
+// buffer is laid out in three contiguous runs - minor lines, major
+// lines, then the X/Z center lines - so Draw can issue one gl.LineWidth
+// per run instead of varying width per vertex (GL has no such thing).
+type Grid struct {
+	program      uint32
+	vao, vbo     uint32
+	modelUniform int32
+
+	minorVertexCount  int32
+	majorVertexCount  int32
+	centerVertexCount int32
+	majorThickness    float32
+
+	fogEnabledUniform int32
+	fogDensityUniform int32
+	fogColorUniform   int32
+	fadeAlphaUniform  int32
+
+	// Spacing is the grid's line spacing, baked in at construction; kept
+	// around so UpdateFollow can snap Offset to it.
+	Spacing float32
+
+	// Offset translates the grid in world space each Draw. UpdateFollow
+	// sets it; it's zero (no effect) otherwise.
+	Offset mgl32.Vec3
+
+	// FadeAlpha multiplies every line's alpha at draw time, on top of the
+	// per-vertex alpha baked in at construction. Defaults to 1 (no
+	// effect); adaptiveGrid uses it to crossfade between adjacent decade
+	// spacings as the camera zooms, instead of baking a fixed Alpha in.
+	FadeAlpha float32
+}
+
+// NewGrid builds a single square grid of the given line count and spacing,
+// drawn in the given color at full opacity.
+func NewGrid(lines int, spacing float32, color mgl32.Vec3) *Grid {
+	return NewGridFromConfig(GridConfig{Spacing: spacing, Lines: lines, Color: color, Alpha: 1.0})
+}
+
+// NewGridFromConfig builds a grid from a GridConfig, baking its color and
+// alpha into the vertex data.
+func NewGridFromConfig(cfg GridConfig) *Grid {
+	if cfg.Lines > maxGridLines {
+		log.Printf("grid: capping %d lines to %d", cfg.Lines, maxGridLines)
+		cfg.Lines = maxGridLines
+	}
+
+	extent := float32(cfg.Lines) * cfg.Spacing
+	// Vertices are bucketed by draw style rather than emitted in index
+	// order, so the final buffer is three contiguous runs Draw can walk
+	// with one gl.LineWidth each: minor, then major, then center.
+	var minorVerts, majorVerts, centerVerts []float32
+	appendLine := func(dst *[]float32, x0, z0, x1, z1 float32, color mgl32.Vec3, alpha float32) {
+		*dst = append(*dst,
+			x0, 0, z0, color.X(), color.Y(), color.Z(), alpha,
+			x1, 0, z1, color.X(), color.Y(), color.Z(), alpha,
+		)
+	}
+	// axisRed/axisBlue match Axes' X/Z colors, at full opacity regardless
+	// of the grid's own Alpha, so the origin reads clearly even on a
+	// translucent coarse grid.
+	axisRed := mgl32.Vec3{1, 0, 0}
+	axisBlue := mgl32.Vec3{0, 0, 1}
+	for i := -cfg.Lines; i <= cfg.Lines; i++ {
+		offset := float32(i) * cfg.Spacing
+		switch {
+		case i == 0:
+			// This line runs parallel to Z (x fixed at 0): matches the Z
+			// axis' blue. The other runs parallel to X: matches red.
+			appendLine(&centerVerts, offset, -extent, offset, extent, axisBlue, 1)
+			appendLine(&centerVerts, -extent, offset, extent, offset, axisRed, 1)
+		case cfg.MajorInterval > 0 && i%cfg.MajorInterval == 0:
+			appendLine(&majorVerts, offset, -extent, offset, extent, cfg.MajorColor, cfg.Alpha)
+			appendLine(&majorVerts, -extent, offset, extent, offset, cfg.MajorColor, cfg.Alpha)
+		default:
+			appendLine(&minorVerts, offset, -extent, offset, extent, cfg.Color, cfg.Alpha)
+			appendLine(&minorVerts, -extent, offset, extent, offset, cfg.Color, cfg.Alpha)
+		}
+	}
+	vertices := append(append(minorVerts, majorVerts...), centerVerts...)
+
+	program, err := newProgram(gridVertexShader, gridFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	vao, vbo := newInterleavedBuffer(vertices, 3, 4)
+
+	majorThickness := cfg.MajorThickness
+	if majorThickness <= 0 {
+		majorThickness = 1
+	}
+
+	return &Grid{
+		program:           program,
+		vao:               vao,
+		vbo:               vbo,
+		minorVertexCount:  int32(len(minorVerts) / 7),
+		majorVertexCount:  int32(len(majorVerts) / 7),
+		centerVertexCount: int32(len(centerVerts) / 7),
+		majorThickness:    majorThickness,
+		modelUniform:      gl.GetUniformLocation(program, gl.Str("model\x00")),
+		fogEnabledUniform: gl.GetUniformLocation(program, gl.Str("fogEnabled\x00")),
+		fogDensityUniform: gl.GetUniformLocation(program, gl.Str("fogDensity\x00")),
+		fogColorUniform:   gl.GetUniformLocation(program, gl.Str("fogColor\x00")),
+		fadeAlphaUniform:  gl.GetUniformLocation(program, gl.Str("fadeAlpha\x00")),
+		Spacing:           cfg.Spacing,
+		FadeAlpha:         1,
+	}
+}
+
+// UpdateFollow snaps Offset to camPos's XZ position, rounded down to
+// the nearest multiple of Spacing, so the grid appears to extend
+// infinitely as the camera moves instead of leaving its fixed extent
+// behind. Snapping to whole grid cells, rather than tracking camPos
+// exactly, means the lines themselves don't swim as the grid moves.
+// When follow is false, Offset resets to zero.
+func (g *Grid) UpdateFollow(camPos mgl32.Vec3, follow bool) {
+	if !follow {
+		g.Offset = mgl32.Vec3{}
+		return
+	}
+	snap := func(v float32) float32 {
+		return float32(math.Floor(float64(v/g.Spacing))) * g.Spacing
+	}
+	g.Offset = mgl32.Vec3{snap(camPos.X()), 0, snap(camPos.Z())}
+}
+
+// NewGridSet builds one Grid per GridConfig, letting multiple grids at
+// different scales be drawn together in a single scene.
+func NewGridSet(configs []GridConfig) []*Grid {
+	grids := make([]*Grid, 0, len(configs))
+	for _, cfg := range configs {
+		grids = append(grids, NewGridFromConfig(cfg))
+	}
+	return grids
+}
+
+// Draw renders the grid's lines, translated by Offset. view/projection
+// come from the shared Matrices UBO (see updateMatricesUBO), not a
+// per-call uniform upload.
+func (g *Grid) Draw() {
+	model := mgl32.Translate3D(g.Offset.X(), g.Offset.Y(), g.Offset.Z())
+	gl.UseProgram(g.program)
+	gl.UniformMatrix4fv(g.modelUniform, 1, false, &model[0])
+	gl.Uniform1i(g.fogEnabledUniform, boolToInt32(fogEnabled))
+	gl.Uniform1f(g.fogDensityUniform, fogDensity)
+	gl.Uniform3f(g.fogColorUniform, fogColor.X(), fogColor.Y(), fogColor.Z())
+	gl.Uniform1f(g.fadeAlphaUniform, g.FadeAlpha)
+
+	blending := beginLineBlend()
+	gl.BindVertexArray(g.vao)
+	setLineWidth(1)
+	gl.DrawArrays(gl.LINES, 0, g.minorVertexCount)
+	if g.majorVertexCount > 0 {
+		setLineWidth(g.majorThickness)
+		gl.DrawArrays(gl.LINES, g.minorVertexCount, g.majorVertexCount)
+	}
+	if g.centerVertexCount > 0 {
+		setLineWidth(defaultCenterThickness)
+		gl.DrawArrays(gl.LINES, g.minorVertexCount+g.majorVertexCount, g.centerVertexCount)
+	}
+	setLineWidth(1)
+	gl.BindVertexArray(0)
+	endLineBlend(blending)
+}
+
+// Delete frees the grid's GL resources.
+func (g *Grid) Delete() {
+	gl.DeleteVertexArrays(1, &g.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &g.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(g.program)
+	trackDelete("program")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// GridHighlight draws a single translucent quad over whichever grid cell
+// the mouse is hovering, as hover feedback.
+type GridHighlight struct {
+	program      uint32
+	vao, vbo     uint32
+	modelUniform int32
+}
+
+// NewGridHighlight allocates the (dynamically updated) quad used to
+// highlight the hovered cell.
+func NewGridHighlight() *GridHighlight {
+	program, err := newProgram(lineVertexShader, lineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	var vao, vbo uint32
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, 6*7*4, nil, gl.DYNAMIC_DRAW)
+
+	const stride = 7 * 4
+	gl.VertexAttribPointerWithOffset(0, 3, gl.FLOAT, false, stride, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(1, 4, gl.FLOAT, false, stride, 3*4)
+	gl.EnableVertexAttribArray(1)
+	gl.BindVertexArray(0)
+
+	return &GridHighlight{
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+	}
+}
+
+// Draw highlights the grid cell of the given size that contains point.
+// view/projection come from the shared Matrices UBO (see
+// updateMatricesUBO).
+func (h *GridHighlight) Draw(point mgl32.Vec3, cellSize float32) {
+	cx := float32(math.Floor(float64(point.X()/cellSize))) * cellSize
+	cz := float32(math.Floor(float64(point.Z()/cellSize))) * cellSize
+	const lift = 0.01
+	color := [4]float32{1, 0.85, 0.2, 0.35}
+
+	vertices := []float32{
+		cx, lift, cz, color[0], color[1], color[2], color[3],
+		cx + cellSize, lift, cz, color[0], color[1], color[2], color[3],
+		cx + cellSize, lift, cz + cellSize, color[0], color[1], color[2], color[3],
+		cx, lift, cz, color[0], color[1], color[2], color[3],
+		cx + cellSize, lift, cz + cellSize, color[0], color[1], color[2], color[3],
+		cx, lift, cz + cellSize, color[0], color[1], color[2], color[3],
+	}
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, h.vbo)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(vertices)*4, gl.Ptr(vertices))
+
+	gl.UseProgram(h.program)
+	identity := mgl32.Ident4()
+	gl.UniformMatrix4fv(h.modelUniform, 1, false, &identity[0])
+
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	gl.BindVertexArray(h.vao)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	gl.Disable(gl.BLEND)
+}
+
+// Delete frees the highlight's GL resources.
+func (h *GridHighlight) Delete() {
+	gl.DeleteVertexArrays(1, &h.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &h.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(h.program)
+	trackDelete("program")
+}
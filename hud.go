@@ -0,0 +1,179 @@
+package main
+
+import (
+	"image"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// hudPadding is how far, in pixels, the HUD's top-left corner sits from
+// the window's top-left corner.
+const hudPadding = float32(10)
+
+// hudLineHeight is the vertical spacing between HUD lines, matching
+// basicfont.Face7x13's own line height plus a little breathing room.
+const hudLineHeight = 16
+
+// HUD is an on-screen text overlay - camera pose, fov, fps - drawn as a
+// screen-space quad independent of the 3D scene's camera, the same way
+// Plot2D's fps graph is. Unlike TextLabel's rasterize-once text, its
+// content changes every frame, so Draw re-rasterizes and re-uploads the
+// texture each call rather than baking it in at construction.
+type HUD struct {
+	texture  uint32
+	vao, vbo uint32
+	program  uint32
+
+	resolutionUniform int32
+	originUniform     int32
+	sizeUniform       int32
+}
+
+// NewHUD compiles the overlay's shader and allocates its (initially
+// empty) texture and a unit quad scaled by the size/origin uniforms in
+// Draw.
+func NewHUD() *HUD {
+	program, err := newProgram(hudVertexShader, hudFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	gl.UseProgram(program)
+	gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("tex\x00")), 0)
+
+	// Unit quad in [0,1]^2; Draw scales it to the rasterized text's pixel
+	// size via the size uniform rather than rebuilding geometry per frame.
+	vertices := []float32{
+		0, 0, 0, 0,
+		1, 0, 1, 0,
+		1, 1, 1, 1,
+		0, 0, 0, 0,
+		1, 1, 1, 1,
+		0, 1, 0, 1,
+	}
+	vao, vbo := newInterleavedBuffer(vertices, 2, 2)
+
+	var texture uint32
+	gl.GenTextures(1, &texture)
+	trackCreate("texture")
+	gl.BindTexture(gl.TEXTURE_2D, texture)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	return &HUD{
+		texture:           texture,
+		vao:               vao,
+		vbo:               vbo,
+		program:           program,
+		resolutionUniform: gl.GetUniformLocation(program, gl.Str("resolution\x00")),
+		originUniform:     gl.GetUniformLocation(program, gl.Str("origin\x00")),
+		sizeUniform:       gl.GetUniformLocation(program, gl.Str("size\x00")),
+	}
+}
+
+// Draw rasterizes lines, one per row, into the top-left corner of the
+// window and draws them with depth testing disabled so the overlay
+// always sits on top of the scene.
+// scale multiplies hudPadding and the rendered quad's size (but not the
+// rasterized texture's own resolution), so the HUD keeps a roughly
+// constant physical size across DPI content scales - see uiScale in
+// contentscale.go. Pass 1 for no scaling.
+func (h *HUD) Draw(lines []string, screenW, screenH int, scale float32) {
+	if len(lines) == 0 {
+		return
+	}
+
+	face := basicfont.Face7x13
+	maxWidth := 0
+	for _, line := range lines {
+		bounds, _ := font.BoundString(face, line)
+		if w := (bounds.Max.X - bounds.Min.X).Ceil(); w > maxWidth {
+			maxWidth = w
+		}
+	}
+	if maxWidth <= 0 {
+		return
+	}
+	height := len(lines) * hudLineHeight
+
+	img := image.NewRGBA(image.Rect(0, 0, maxWidth, height))
+	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.White,
+		Face: face,
+	}
+	for i, line := range lines {
+		drawer.Dot = fixed.P(0, i*hudLineHeight+face.Metrics().Ascent.Ceil())
+		drawer.DrawString(line)
+	}
+
+	gl.UseProgram(h.program)
+	gl.Uniform2f(h.resolutionUniform, float32(screenW), float32(screenH))
+	gl.Uniform2f(h.originUniform, hudPadding*scale, hudPadding*scale)
+	gl.Uniform2f(h.sizeUniform, float32(maxWidth)*scale, float32(height)*scale)
+
+	gl.BindTexture(gl.TEXTURE_2D, h.texture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(maxWidth), int32(height), 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+
+	gl.Disable(gl.DEPTH_TEST)
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindVertexArray(h.vao)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	gl.Disable(gl.BLEND)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+// Delete frees the overlay's GL resources.
+func (h *HUD) Delete() {
+	gl.DeleteTextures(1, &h.texture)
+	trackDelete("texture")
+	gl.DeleteVertexArrays(1, &h.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &h.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(h.program)
+	trackDelete("program")
+}
+
+const hudVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 aPos;
+layout (location = 1) in vec2 aUV;
+
+uniform vec2 resolution;
+uniform vec2 origin;
+uniform vec2 size;
+
+out vec2 vUV;
+
+void main() {
+	vec2 pixel = origin + aPos * size;
+	vec2 ndc = vec2(
+		(pixel.x / resolution.x) * 2.0 - 1.0,
+		1.0 - (pixel.y / resolution.y) * 2.0
+	);
+	gl_Position = vec4(ndc, 0.0, 1.0);
+	vUV = aUV;
+}
+` + "\x00"
+
+const hudFragmentShader = `
+#version 460 core
+in vec2 vUV;
+out vec4 FragColor;
+
+uniform sampler2D tex;
+
+void main() {
+	FragColor = texture(tex, vUV);
+}
+` + "\x00"
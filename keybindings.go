@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// KeyBindings maps each bindable action to the glfw.Key that triggers
+// it, so players can remap movement for non-QWERTY layouts or
+// preference instead of living with the literal glfw.KeyW et al.
+// processInput and keyCallback consult this rather than hardcoded keys.
+type KeyBindings struct {
+	Forward glfw.Key
+	Back    glfw.Key
+	Left    glfw.Key
+	Right   glfw.Key
+	Up      glfw.Key
+	Down    glfw.Key
+	Reset   glfw.Key
+	Quit    glfw.Key
+}
+
+// DefaultKeyBindings is what LoadKeyBindings falls back to when -keys
+// isn't given, or falls back to per-action when the file omits one.
+var DefaultKeyBindings = KeyBindings{
+	Forward: glfw.KeyW,
+	Back:    glfw.KeyS,
+	Left:    glfw.KeyA,
+	Right:   glfw.KeyD,
+	Up:      glfw.KeySpace,
+	Down:    glfw.KeyLeftShift,
+	Reset:   glfw.KeyH,
+	Quit:    glfw.KeyEscape,
+}
+
+// keyBindingsJSON mirrors KeyBindings with string key names, since
+// glfw.Key has no JSON encoding of its own.
+type keyBindingsJSON struct {
+	Forward string `json:"forward"`
+	Back    string `json:"back"`
+	Left    string `json:"left"`
+	Right   string `json:"right"`
+	Up      string `json:"up"`
+	Down    string `json:"down"`
+	Reset   string `json:"reset"`
+	Quit    string `json:"quit"`
+}
+
+// LoadKeyBindings reads a JSON key bindings file at path, overlaying it
+// onto DefaultKeyBindings so any action the file omits (or names a key
+// parseKeyName doesn't recognize) keeps its default. An empty path
+// returns the defaults unchanged.
+func LoadKeyBindings(path string) (KeyBindings, error) {
+	bindings := DefaultKeyBindings
+	if path == "" {
+		return bindings, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return bindings, fmt.Errorf("read key bindings: %w", err)
+	}
+	var raw keyBindingsJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return bindings, fmt.Errorf("parse key bindings: %w", err)
+	}
+
+	apply := func(name string, dst *glfw.Key) {
+		if name == "" {
+			return
+		}
+		if key, ok := parseKeyName(name); ok {
+			*dst = key
+		}
+	}
+	apply(raw.Forward, &bindings.Forward)
+	apply(raw.Back, &bindings.Back)
+	apply(raw.Left, &bindings.Left)
+	apply(raw.Right, &bindings.Right)
+	apply(raw.Up, &bindings.Up)
+	apply(raw.Down, &bindings.Down)
+	apply(raw.Reset, &bindings.Reset)
+	apply(raw.Quit, &bindings.Quit)
+	return rejectConflictingBindings(bindings), nil
+}
+
+// reservedActionKeys are the glfw.Key values keyCallback's main switch
+// hardcodes for actions that aren't part of KeyBindings (toggling the
+// console, grid, axes, ...). rejectConflictingBindings checks every
+// remappable action against this list, since a key bound here collides
+// with the hardcoded action - the Go switch in keyCallback tries cases in
+// source order, so keyBindings.Reset's own case loses to every one of
+// these except KeyF5/KeyF8, whose cases sit after it and would be
+// shadowed by it instead. Keep this in sync with keyCallback's case keys.
+var reservedActionKeys = []glfw.Key{
+	glfw.KeyGraveAccent, glfw.KeyL, glfw.KeyP, glfw.KeyO, glfw.KeyM, glfw.KeyN,
+	glfw.KeyJ, glfw.KeyY, glfw.KeyR, glfw.KeyPeriod, glfw.KeyComma, glfw.KeySemicolon,
+	glfw.KeyKp1, glfw.KeyKp3, glfw.KeyKp7, glfw.KeyG, glfw.KeyF6,
+	glfw.Key1, glfw.Key2, glfw.Key3, glfw.Key4, glfw.Key5, glfw.KeyF,
+	glfw.KeyK, glfw.KeyT, glfw.KeyU, glfw.KeyI, glfw.KeyC, glfw.KeyB,
+	glfw.KeyZ, glfw.KeyX, glfw.KeyTab, glfw.KeyV, glfw.KeyF12, glfw.KeyF9,
+	glfw.KeyF5, glfw.KeyF8,
+}
+
+// rejectConflictingBindings reverts any bindings field whose key
+// collides with reservedActionKeys, or with an earlier field in this
+// same struct, back to DefaultKeyBindings' value for that field,
+// logging each one - the same kind of silent shadowing as a measure mode
+// toggle on KeyH clashing with keyBindings.Reset's KeyH default, just
+// generalized to catch it for every action instead of only Reset.
+// Fields are checked in KeyBindings' declared order, matching Go's
+// first-match switch semantics in keyCallback.
+func rejectConflictingBindings(bindings KeyBindings) KeyBindings {
+	fields := []struct {
+		name string
+		key  *glfw.Key
+	}{
+		{"forward", &bindings.Forward},
+		{"back", &bindings.Back},
+		{"left", &bindings.Left},
+		{"right", &bindings.Right},
+		{"up", &bindings.Up},
+		{"down", &bindings.Down},
+		{"reset", &bindings.Reset},
+		{"quit", &bindings.Quit},
+	}
+	defaults := map[string]glfw.Key{
+		"forward": DefaultKeyBindings.Forward,
+		"back":    DefaultKeyBindings.Back,
+		"left":    DefaultKeyBindings.Left,
+		"right":   DefaultKeyBindings.Right,
+		"up":      DefaultKeyBindings.Up,
+		"down":    DefaultKeyBindings.Down,
+		"reset":   DefaultKeyBindings.Reset,
+		"quit":    DefaultKeyBindings.Quit,
+	}
+
+	seen := map[glfw.Key]bool{}
+	for _, k := range reservedActionKeys {
+		seen[k] = true
+	}
+	for _, f := range fields {
+		if seen[*f.key] {
+			log.Printf("key binding %q: %v is already used elsewhere; keeping default %v", f.name, *f.key, defaults[f.name])
+			*f.key = defaults[f.name]
+		}
+		seen[*f.key] = true
+	}
+	return bindings
+}
+
+// parseKeyName resolves a JSON key binding name to a glfw.Key. Single
+// letters map directly (GLFW's A-Z key codes are contiguous and match
+// ASCII); a handful of named keys cover the rest of what a movement/
+// reset/quit scheme needs.
+func parseKeyName(name string) (glfw.Key, bool) {
+	switch strings.ToUpper(name) {
+	case "SPACE":
+		return glfw.KeySpace, true
+	case "SHIFT", "LEFTSHIFT":
+		return glfw.KeyLeftShift, true
+	case "CTRL", "CONTROL", "LEFTCONTROL":
+		return glfw.KeyLeftControl, true
+	case "ESC", "ESCAPE":
+		return glfw.KeyEscape, true
+	case "TAB":
+		return glfw.KeyTab, true
+	}
+	if len(name) == 1 {
+		r := strings.ToUpper(name)[0]
+		if r >= 'A' && r <= 'Z' {
+			return glfw.Key(int(glfw.KeyA) + int(r-'A')), true
+		}
+	}
+	return 0, false
+}
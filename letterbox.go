@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// aspectFlag forces the rendered scene into a centered sub-rectangle of
+// the window matching a target aspect ratio, e.g. "16:9", with the
+// leftover space (the letterbox bars) cleared to black - for
+// screenshots/recordings that must match a fixed framing regardless of
+// the window size they were taken at. Unset (the default) renders into
+// the full framebuffer, same as before this existed. Only wired into
+// the single-viewport path (see Run); LayoutQuad already splits the
+// framebuffer into four panes of its own, so a single target ratio
+// doesn't apply there.
+var aspectFlag = flag.String("aspect", "", `force a centered letterboxed viewport at this aspect ratio, as "W:H" (e.g. "16:9")`)
+
+var (
+	letterboxEnabled bool
+	letterboxRatio   float32
+)
+
+// initLetterbox parses -aspect, if set.
+func initLetterbox() {
+	if *aspectFlag == "" {
+		return
+	}
+	ratio, err := parseAspectRatio(*aspectFlag)
+	if err != nil {
+		log.Printf("parse -aspect: %v; letterboxing disabled", err)
+		return
+	}
+	letterboxRatio = ratio
+	letterboxEnabled = true
+}
+
+// parseAspectRatio parses "W:H" into W/H.
+func parseAspectRatio(s string) (float32, error) {
+	w, h, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, fmt.Errorf("%q: want \"W:H\"", s)
+	}
+	wf, err := strconv.ParseFloat(w, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q: %w", s, err)
+	}
+	hf, err := strconv.ParseFloat(h, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q: %w", s, err)
+	}
+	if wf <= 0 || hf <= 0 {
+		return 0, fmt.Errorf("%q: width and height must be positive", s)
+	}
+	return float32(wf / hf), nil
+}
+
+// letterboxRect fits the largest centered rectangle of the given ratio
+// (width/height) inside an fbWidth x fbHeight framebuffer.
+func letterboxRect(fbWidth, fbHeight int, ratio float32) (x, y, w, h int32) {
+	if fbWidth <= 0 || fbHeight <= 0 {
+		return 0, 0, int32(fbWidth), int32(fbHeight)
+	}
+	fbRatio := float32(fbWidth) / float32(fbHeight)
+	if fbRatio > ratio {
+		// Window is wider than the target: bars on left/right.
+		w = int32(float32(fbHeight) * ratio)
+		h = int32(fbHeight)
+		x = (int32(fbWidth) - w) / 2
+	} else {
+		// Window is taller (or equal): bars on top/bottom.
+		w = int32(fbWidth)
+		h = int32(float32(fbWidth) / ratio)
+		y = (int32(fbHeight) - h) / 2
+	}
+	return x, y, w, h
+}
+
+// clearLetterbox fills the whole framebuffer black for the letterbox
+// bars, then re-clears just the inner viewport rect with the real
+// background so the scene's own clear isn't visibly different from the
+// unletterboxed path. Scissor-limiting the second clear is the same
+// technique drawQuadViewports uses to clear one pane at a time.
+func clearLetterbox(x, y, w, h int32) {
+	gl.ClearColor(0, 0, 0, 1)
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	gl.Enable(gl.SCISSOR_TEST)
+	gl.Scissor(x, y, w, h)
+	gl.ClearColor(clearColor[0], clearColor[1], clearColor[2], 1.0)
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	gl.Disable(gl.SCISSOR_TEST)
+}
@@ -0,0 +1,29 @@
+package main
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// Light is a directional light used for scene shading and (eventually)
+// shadow mapping. Position and Target double as an eye/look-at pair when
+// rendering the scene from the light's point of view.
+type Light struct {
+	Position mgl32.Vec3
+	Target   mgl32.Vec3
+	Up       mgl32.Vec3
+}
+
+// NewLight creates a directional light aimed from position at target.
+func NewLight(position, target mgl32.Vec3) *Light {
+	return &Light{Position: position, Target: target, Up: mgl32.Vec3{0, 1, 0}}
+}
+
+// ViewMatrix returns the light's view matrix, as used both for shading and
+// for the light's-eye-view debug camera.
+func (l *Light) ViewMatrix() mgl32.Mat4 {
+	return mgl32.LookAtV(l.Position, l.Target, l.Up)
+}
+
+// ProjectionMatrix returns an orthographic projection wide enough to cover
+// the default scene extent.
+func (l *Light) ProjectionMatrix() mgl32.Mat4 {
+	return mgl32.Ortho(-20, 20, -20, 20, 0.1, 100)
+}
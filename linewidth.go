@@ -0,0 +1,98 @@
+package main
+
+import (
+	"log"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// lineWidthScale multiplies every setLineWidth call, seeded from the
+// -linewidth flag in Init. 1.0 (the default) leaves each object's own
+// baseline thickness (Grid's minor/major/center, Axes' per-axis
+// thickness, WireCube's outline) unchanged.
+var lineWidthScale = float32(1.0)
+
+// lineWidthRange is the driver's supported GL line width range, queried
+// once via queryLineWidthRange after gl.Init. Defaults to [1, 1] (the
+// common core-profile floor) until that query runs, so setLineWidth
+// clamps sanely even if called before it.
+var lineWidthRange = [2]float32{1, 1}
+
+// queryLineWidthRange reads GL_ALIASED_LINE_WIDTH_RANGE. Must be called
+// after gl.Init; core-profile drivers commonly report [1, 1] here, since
+// wide lines are a deprecated fixed-function feature - see setLineWidth.
+func queryLineWidthRange() [2]float32 {
+	var r [2]float32
+	gl.GetFloatv(gl.ALIASED_LINE_WIDTH_RANGE, &r[0])
+	return r
+}
+
+// initLineWidth queries the driver's line width range and warns if it
+// can't satisfy lineWidthScale, since a driver that reports [1, 1]
+// silently ignores any gl.LineWidth call above 1px in core profile.
+// Axes sidesteps this entirely with a geometry-shader thick-line
+// approach (see thickLineGeometryShader) rather than gl.LineWidth, since
+// its thickness matters most for reading the scene at a glance; Grid and
+// WireCube's outline still go through setLineWidth below and so remain
+// subject to this driver limitation.
+func initLineWidth() {
+	lineWidthRange = queryLineWidthRange()
+	if lineWidthRange[1] <= 1 && lineWidthScale > 1 {
+		log.Printf("driver reports GL_ALIASED_LINE_WIDTH_RANGE = %v; -linewidth %.1f will have no visible effect on lines wider than 1px (core profile drivers commonly don't support wide lines)", lineWidthRange, lineWidthScale)
+	}
+}
+
+// setLineWidth scales base by lineWidthScale and uiScale (the window's
+// DPI content scale; see contentscale.go), clamps the result to
+// lineWidthRange, and applies it. Every gl.LineWidth call in the package
+// goes through this instead of calling it directly, so -linewidth
+// affects every line-drawing object uniformly and lines keep a
+// consistent physical thickness across displays.
+func setLineWidth(base float32) {
+	gl.LineWidth(clamp32(base*lineWidthScale*uiScale(), lineWidthRange[0], lineWidthRange[1]))
+}
+
+// lineBlendEnabled and lineBlendSrc/lineBlendDst cache the -line-smooth/
+// -line-blend/-line-blend-func flags, parsed once by initLineBlend, so
+// beginLineBlend doesn't touch flag.Value on every line draw call.
+var (
+	lineBlendEnabled           bool
+	lineBlendSrc, lineBlendDst uint32
+)
+
+// initLineBlend must be called once after gl.Init, after the -line-smooth
+// toggle in Init. Blending is only worth enabling alongside LINE_SMOOTH
+// itself - see lineBlendFlag's doc comment in main.go for why the two go
+// together, and for what -line-blend-func's two options trade off.
+func initLineBlend() {
+	lineBlendEnabled = *lineSmoothFlag && *lineBlendFlag
+	lineBlendSrc, lineBlendDst = gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA
+	if *lineBlendFuncFlag == "additive" {
+		lineBlendDst = gl.ONE
+	}
+}
+
+// beginLineBlend enables alpha blending with the configured function, if
+// -line-blend is on, and reports whether it did so. It's scoped around
+// individual line draw calls (Grid, Axes, WireCube's wireframe edges,
+// MeasureTool, OriginGizmo) rather than left enabled for the whole
+// frame, matching gridhighlight.go/hud.go/textlabel.go's existing
+// per-draw blend usage - leaving -line-blend-func additive enabled
+// globally would double-brighten every opaque triangle drawn while it's
+// active, not just lines. Callers must pass the returned bool to a
+// matching endLineBlend call.
+func beginLineBlend() bool {
+	if !lineBlendEnabled {
+		return false
+	}
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(lineBlendSrc, lineBlendDst)
+	return true
+}
+
+// endLineBlend undoes beginLineBlend; pass the bool it returned.
+func endLineBlend(enabled bool) {
+	if enabled {
+		gl.Disable(gl.BLEND)
+	}
+}
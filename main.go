@@ -0,0 +1,1686 @@
+// Command viewer is a small OpenGL scene viewer: a camera flying over a
+// reference grid and coordinate axes, used as a sandbox for rendering
+// experiments (meshes, post-processing, picking, ...).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// modelPath, if set, is an OBJ file to load and draw at the origin
+// alongside the existing grid/axes/cube primitives.
+var modelPath = flag.String("model", "", "path to a Wavefront OBJ file to load and render at the origin")
+
+// weldToleranceFlag is how far apart (in the OBJ's own units) two "v"
+// positions can be before loadOBJ treats them as the same point and
+// merges them; see CleanTriangles in meshclean.go. 0 disables welding
+// but still drops zero-area degenerate triangles.
+var weldToleranceFlag = flag.Float64("weld-tolerance", 0, "merge -model vertices within this distance of each other, and drop degenerate triangles")
+
+// bgColor overrides the default clear color, e.g. for screenshots
+// against a plain white or custom background. Parsed by parseHexColor;
+// an invalid value logs a warning and keeps defaultBGColor.
+var bgColor = flag.String("bg", "", "clear color as #RRGGBB (default: dark gray)")
+
+// defaultBGColor is the clear color used when -bg is unset or invalid.
+var defaultBGColor = [3]float32{0.08, 0.09, 0.11}
+
+// clearColor is the resolved -bg color, recorded so letterboxing (see
+// letterbox.go) can briefly switch gl.ClearColor to black for the
+// letterbox bars and restore the real background for the scene's own
+// clear.
+var clearColor [3]float32
+
+// crosshairSizeFlag/crosshairColorFlag configure the fly-mode reticle
+// (see crosshair.go); crosshairVisible toggles it on/off at runtime with
+// glfw.KeyY, starting visible since it's most useful exactly when
+// WalkMode is already on.
+var crosshairSizeFlag = flag.Float64("crosshair-size", 8, "crosshair arm length in pixels")
+var crosshairColorFlag = flag.String("crosshair-color", "", "crosshair color as #RRGGBB (default: white)")
+
+var (
+	crosshair        *Crosshair
+	crosshairVisible = true
+	crosshairSize    float32
+	crosshairColor   = [3]float32{1, 1, 1}
+)
+
+// bgTopColor/bgBottomColor enable a top-to-bottom gradient background
+// drawn on a full-screen quad (see background.go) instead of the plain
+// -bg clear color. Only -bg-top is required to turn the gradient on;
+// -bg-bottom falls back to the solid -bg/defaultBGColor, so e.g. a sky
+// that fades from blue down to the usual dark gray needs one flag, not
+// two. A proper cubemap skybox (six images via gl.TEXTURE_CUBE_MAP) is
+// future work - the gradient covers the "dull solid color" complaint
+// this was asked for without the extra asset-loading machinery.
+var bgTopColor = flag.String("bg-top", "", "top gradient color as #RRGGBB; set to enable a gradient background")
+var bgBottomColor = flag.String("bg-bottom", "", "bottom gradient color as #RRGGBB (default: -bg/dark gray)")
+
+// headless, if set, skips the interactive window and render loop
+// entirely: it renders exactly one frame from the fixed home camera
+// pose, writes it to outPath, and exits. Intended for golden-image
+// regression tests on a machine with no display server.
+var headless = flag.Bool("headless", false, "render a single frame offscreen and exit, for CI screenshot tests")
+
+// outPath is where -headless writes its rendered frame.
+var outPath = flag.String("out", "frame.png", "output path for -headless's rendered frame")
+
+// recordDir/recordFrames drive -record: like -headless, it renders
+// offscreen and exits rather than opening an interactive window, but
+// renders recordFrames frames along a scripted orbit (see
+// renderRecording) instead of one static frame, writing each to a
+// numbered PNG in recordDir suitable for assembling into a video, e.g.
+// `ffmpeg -framerate 60 -i frame-%05d.png out.mp4`.
+var (
+	recordDir    = flag.String("record", "", "directory to write numbered PNG frames to for video recording")
+	recordFrames = flag.Int("frames", 0, "number of frames to render for -record")
+)
+
+// stressCount/stressDuration drive -stress: like -headless, it renders
+// offscreen rather than opening an interactive window, but instead of
+// producing an image it spawns stressCount cubes at random positions
+// (see renderStress) and reports frame time statistics over
+// stressDuration, for profiling the draw loop under load.
+var (
+	stressCount    = flag.Int("stress", 0, "spawn N cubes at random positions and report frame time stats instead of rendering interactively")
+	stressDuration = flag.Duration("stress-duration", 5*time.Second, "how long to run -stress before reporting stats and exiting")
+)
+
+// vertPath/fragPath, if both set, load a custom shader pair for the
+// loaded model from disk instead of the built-in meshVertexShader/
+// meshFragmentShader, so it can be iterated on without recompiling.
+var (
+	vertPath = flag.String("vert", "", "path to a custom vertex shader for the loaded model (requires -frag)")
+	fragPath = flag.String("frag", "", "path to a custom fragment shader for the loaded model (requires -vert)")
+)
+
+// keysPath, if set, is a JSON file of key bindings to load over
+// DefaultKeyBindings; see LoadKeyBindings.
+var keysPath = flag.String("keys", "", "path to a JSON key bindings file")
+
+// texturePath, if set, is a PNG/JPEG image to load as a reference image
+// (a floor plan, a blueprint) and draw on a quad lying flat in the XZ
+// plane at the origin, sized by textureSize.
+var (
+	texturePath = flag.String("texture", "", "path to a PNG/JPEG image to display as a ground-plane quad")
+	textureSize = flag.Float64("texture-size", 10, "world-space side length of the -texture quad")
+)
+
+// scenePath, if set, is a JSON scene file (see loadScene) listing extra
+// primitives - cubes, grids, lines, OBJ models - to render alongside the
+// built-in grid/axes/cube, each with its own position/rotation/scale/
+// color. The built-in scene is always drawn regardless; a scene file
+// only adds to it, rather than replacing it, so -scene is a way to
+// furnish the viewer rather than a from-scratch scene description.
+var scenePath = flag.String("scene", "", "path to a JSON scene file of extra primitives to render")
+
+// maxFPS, if positive, caps the frame rate by sleeping out the rest of
+// each frame in the main loop. 0 (the default) leaves the loop uncapped
+// aside from vsync.
+var maxFPS = flag.Int("maxfps", 0, "cap the frame rate to N fps (0 = uncapped)")
+
+// axisGradientFlag opts axes.go's axis lines into dark-at-origin,
+// bright-at-tip per-vertex shading with a dimmed negative half, instead
+// of each axis' flat single color.
+var axisGradientFlag = flag.Bool("axis-gradient", false, "shade axes dark-to-bright from origin to tip, with negative halves dimmed")
+
+// pauseOnUnfocusFlag opts into blocking the main loop on glfw.WaitEvents
+// while the window lacks focus, instead of spinning the render loop at
+// full speed for a window nobody's looking at. Off by default since an
+// interactive user switching windows briefly shouldn't be surprised by
+// the app going unresponsive until they switch back; see
+// pauseunfocus.go.
+var pauseOnUnfocusFlag = flag.Bool("pause-on-unfocus", false, "block on input instead of rendering while the window is unfocused")
+
+// widthFlag/heightFlag/titleFlag override windowWidth/windowHeight/
+// windowTitle's compile-time defaults at startup. Invalid (non-positive)
+// width/height values are rejected in Init, falling back to the default.
+var (
+	widthFlag  = flag.Int("width", defaultWindowWidth, "window width in pixels")
+	heightFlag = flag.Int("height", defaultWindowHeight, "window height in pixels")
+	titleFlag  = flag.String("title", defaultWindowTitle, "window title")
+)
+
+// quatCameraFlag switches the camera's orientation from yaw/pitch Euler
+// angles to a quaternion (see Camera.QuaternionMode), enabling roll
+// (Q/E in processInput) and removing the ±89° pitch clamp, for full
+// 6-DOF "spaceship" navigation. Off by default so existing mouse-look
+// behavior is unchanged unless asked for.
+var quatCameraFlag = flag.Bool("quatcam", false, "use quaternion camera orientation (6-DOF, with roll) instead of yaw/pitch Euler angles")
+
+// verticalLocalUpFlag sets Camera.VerticalLocalUp: whether Space/Shift
+// rise/fall along the camera's own Up instead of true world up. Only
+// matters in -quatcam mode after a roll; see VerticalLocalUp.
+var verticalLocalUpFlag = flag.Bool("vertical-local-up", false, "move Space/Shift along the camera's own up vector instead of world up")
+
+// movementFrameFlag sets Camera.MovementFrame, overriding the default
+// OrbitEnabled-based choice between moving the camera and panning
+// OrbitTarget; see MovementFrame's doc comment for what each option
+// does. parseMovementFrame maps the flag string to the enum at startup.
+var movementFrameFlag = flag.String("movement-frame", "camera", `WASD movement frame: "camera" (orbit pans target, otherwise moves relative to view), "world" (always world X/Z, regardless of view or orbit), or "target-pan" (always pans the orbit target)`)
+
+// parseMovementFrame maps -movement-frame's string value to a
+// MovementFrame, warning and falling back to MovementFrameCamera on an
+// unrecognized value rather than failing startup outright.
+func parseMovementFrame(s string) MovementFrame {
+	switch s {
+	case "camera":
+		return MovementFrameCamera
+	case "world":
+		return MovementFrameWorld
+	case "target-pan":
+		return MovementFrameTargetPan
+	default:
+		log.Printf("unrecognized -movement-frame %q; using \"camera\"", s)
+		return MovementFrameCamera
+	}
+}
+
+// sensitivityFlag/invertYFlag seed Camera.Sensitivity/InvertY at
+// startup; see mouseCallback.
+var (
+	sensitivityFlag = flag.Float64("sensitivity", 0.1, "mouse look sensitivity")
+	invertYFlag     = flag.Bool("invert-y", false, "invert the Y axis for mouse look")
+)
+
+// cameraFlag jumps the camera to an exact viewpoint at startup, as
+// "x,y,z,yaw,pitch" (see parseCameraCoords) - the flag equivalent of
+// the F8 coordinate-entry mode (see startCameraInput), useful for
+// reproducing an exact view from a bug report without re-typing it
+// interactively every launch. Takes priority over a saved
+// defaultCameraStatePath and skips the fly-in intro, same as -headless.
+var cameraFlag = flag.String("camera", "", "jump to an exact viewpoint at startup: \"x,y,z,yaw,pitch\"")
+
+// nearFlag/farFlag set the camera's perspective near/far planes, read
+// into nearPlane/farPlane once at startup. The prior hardcoded 0.1/500.0
+// clipped scenes larger than 500 units and z-fighting appeared on scenes
+// packed close to the near plane; see maxDepthRatio for the warning that
+// catches values likely to reintroduce the latter.
+var (
+	nearFlag = flag.Float64("near", 0.1, "camera near clip plane")
+	farFlag  = flag.Float64("far", 500.0, "camera far clip plane")
+)
+
+// nearPlane/farPlane are set from nearFlag/farFlag in Init and read by
+// every ProjectionMatrix call in place of the old hardcoded 0.1/500.0.
+var nearPlane, farPlane float32
+
+// fovMinFlag/fovMaxFlag set the range ProcessScroll clamps Camera.Fov
+// to, in place of the prior hardcoded [1, 90]. Raising fovMax much past
+// 90 introduces strong perspective distortion (straight lines bowing
+// near the frame edges) - that's a property of wide-angle projection,
+// not a bug, but it's worth knowing before reaching for e.g. 120.
+var (
+	fovMinFlag = flag.Float64("fov-min", 1, "minimum zoom fov in degrees")
+	fovMaxFlag = flag.Float64("fov-max", 90, "maximum zoom fov in degrees (past ~90 perspective distortion gets strong)")
+)
+
+// fovMin/fovMax are set from fovMinFlag/fovMaxFlag in Init and read by
+// Camera.ProcessScroll in place of the old hardcoded 1/90.
+var fovMin, fovMax float32 = 1, 90
+
+// maxDepthRatio is the far/near ratio above which depth-buffer precision
+// loss becomes visible as z-fighting on a standard 24-bit depth buffer -
+// a well-known rule of thumb, not a hard cliff. A logarithmic depth
+// buffer (remapping gl_Position.z/w in every vertex shader to spread
+// precision logarithmically instead of linearly) would raise this
+// ceiling by orders of magnitude, but doing that properly touches every
+// shader in the package, not just this flag; left as a documented
+// option for a future change rather than attempted here.
+const maxDepthRatio = 10000.0
+
+// linewidthFlag/lineSmoothFlag tune how Grid/Axes/WireCube's lines are
+// rasterized. -linewidth scales every line's own baseline thickness (see
+// setLineWidth); core-profile drivers commonly ignore anything above 1px
+// (see initLineWidth). -line-smooth toggles GL_LINE_SMOOTH's antialiasing
+// hint, independent of width.
+var (
+	linewidthFlag  = flag.Float64("linewidth", 1.0, "scale factor applied to every line's width (core-profile drivers commonly ignore widths > 1px)")
+	lineSmoothFlag = flag.Bool("line-smooth", true, "enable GL_LINE_SMOOTH antialiasing for lines")
+)
+
+// lineBlendFlag/lineBlendFuncFlag configure the alpha blending
+// GL_LINE_SMOOTH needs to actually look antialiased: it works by writing
+// fractional pixel coverage into each fragment's alpha channel, and
+// without blending enabled that coverage is simply discarded, leaving
+// edges just as jagged as with -line-smooth off - see beginLineBlend.
+// -line-blend-func picks how overlapping edges combine: "alpha" is
+// standard over-compositing, correct for occasional overlaps but
+// visibly order-dependent in dense line scenes (a farther line's edge
+// can show through a nearer one's, or vice versa, depending on draw
+// order); "additive" sums edge brightness instead, which looks the same
+// regardless of draw order but washes out towards white wherever many
+// edges overlap - e.g. a fine grid's intersections. Neither is a real
+// fix for order-independent transparency; an actual depth prepass
+// (opaque lines first with blending off, then a second additive pass for
+// just the antialiased edges) would avoid the washout, but that doubles
+// every line draw call for a cosmetic gain not attempted here.
+var (
+	lineBlendFlag     = flag.Bool("line-blend", true, "enable alpha blending for lines while -line-smooth is active, so antialiased edges composite correctly")
+	lineBlendFuncFlag = flag.String("line-blend-func", "alpha", `blend function for antialiased line edges: "alpha" (order-dependent compositing) or "additive" (order-independent, washes out in dense overlaps)`)
+)
+
+// animateResetFlag enables Camera.AnimateReset, easing the keyBindings.Reset
+// ("home") key's jump back to the default view over resetAnimationDuration
+// seconds instead of snapping to it instantly. Off by default so existing
+// muscle memory (an instant snap on H) doesn't change underfoot.
+var animateResetFlag = flag.Bool("animate-reset", false, "ease the camera reset (H by default) back to the default view instead of snapping instantly")
+
+// resetAnimationDuration is how long the eased reset takes when
+// -animate-reset is set; see Camera.ResetDuration.
+const resetAnimationDuration = 0.3
+
+// fixedTimestepFlag/timestepFlag decouple advanceSimulation (zoom/look
+// easing, the reset animation, spin) from the render loop's variable
+// frame rate: instead of feeding it whatever deltaTime the last frame
+// took, accumulate real time and step it in fixed timestepFlag-sized
+// increments, so an animation recorded at 30fps and one recorded at 144fps
+// land on exactly the same poses at exactly the same simulated times.
+// Off by default - interactive use wants to track wall-clock time as
+// closely as possible, which the existing variable-timestep update
+// already does.
+var (
+	fixedTimestepFlag = flag.Bool("fixed-timestep", false, "advance animation/camera-path updates in fixed steps accumulated against real time, instead of varying with frame rate")
+	timestepFlag      = flag.Float64("timestep", 1.0/60.0, "fixed update step in seconds, used when -fixed-timestep is set")
+)
+
+// timestepAccumulator banks leftover real time between fixed steps when
+// -fixed-timestep is set; see advanceSimulation's caller in Run.
+var timestepAccumulator float64
+
+// msaaFlag requests N-sample multisampling on the window's own
+// framebuffer (distinct from postPipeline's offscreen MSAA used by
+// AAMSAA — this smooths edges in every AA mode, including AANone, since
+// it applies to the default framebuffer itself). Validated against
+// validMSAASamples in Init; an unsupported count is silently downgraded
+// by the driver, since GLFW's Samples hint has no query for "will this
+// work" before window creation.
+var msaaFlag = flag.Int("msaa", 4, "MSAA samples for the window framebuffer (0, 2, 4, or 8)")
+
+// vsyncEnabled tracks whether SwapInterval is 1 (vsync on, the default)
+// or 0, so the vsync toggle key knows which way to flip it.
+var vsyncEnabled = true
+
+// infiniteGrid, when true, recenters each grid's XZ offset on the camera
+// every frame (see Grid.UpdateFollow) so it appears to extend forever
+// instead of leaving its fixed extent behind. Off by default.
+var infiniteGrid = false
+
+// fogEnabled, fogDensity, and fogColor control the grid's distance fog
+// (see gridFragmentShader), blending distant grid lines toward fogColor
+// so they read as receding rather than just dimming out at a hard edge.
+// fogColor defaults to the clear color (set in Init once -bg is parsed)
+// so the fog fades the grid into the background rather than introducing
+// a visible color seam. On by default; toggled with F.
+var (
+	fogEnabled = true
+	fogDensity = float32(0.05)
+	fogColor   = mgl32.Vec3{defaultBGColor[0], defaultBGColor[1], defaultBGColor[2]}
+)
+
+// drawnObjects/culledObjects count how many Meshes passed or failed the
+// frustum test last frame, reset each frame and shown in the title bar.
+var drawnObjects, culledObjects int
+
+// keyBindings is the active key bindings, populated in Init once
+// keysPath is known.
+var keyBindings KeyBindings
+
+// defaultWindowWidth/defaultWindowHeight/defaultWindowTitle are the
+// window dimensions and title used unless overridden by -width/-height/
+// -title (see widthFlag/heightFlag/titleFlag). The rest of the code
+// reads the runtime windowWidth/windowHeight/windowTitle variables
+// below, populated from the flags in main, rather than these constants
+// directly, so a non-default size takes effect everywhere (aspect
+// ratio, cursor centering, the window itself).
+const (
+	defaultWindowWidth  = 1280
+	defaultWindowHeight = 720
+	defaultWindowTitle  = "viewer"
+)
+
+// windowWidth/windowHeight/windowTitle hold the effective window size
+// and title for the running process, set in Init from -width/-height/
+// -title (falling back to the defaultWindow* constants above when unset
+// or invalid).
+var (
+	windowWidth  = defaultWindowWidth
+	windowHeight = defaultWindowHeight
+	windowTitle  = defaultWindowTitle
+)
+
+// spawnPos is the camera's resting position once the startup fly-in
+// animation (see introFrom) finishes.
+var spawnPos = mgl32.Vec3{0, 2, 6}
+
+// homePos/homeYaw/homePitch/homeFov are the camera's "home" pose, which
+// the keyBindings.Reset key returns to (see Camera.StartReset). It's intentionally distinct from spawnPos:
+// the spawn pose is where the session starts, home is wherever the user
+// considers the canonical view of the scene.
+var (
+	homePos   = mgl32.Vec3{0, 2, 6}
+	homeYaw   = float32(-90)
+	homePitch = float32(0)
+	homeFov   = float32(45)
+)
+
+// camera is the single active viewpoint. It starts out nil and is
+// constructed in Init once introFrom is known.
+var camera *Camera
+
+var (
+	lastFrame  float64
+	deltaTime  float64
+	lastX      = float64(windowWidth) / 2
+	lastY      = float64(windowHeight) / 2
+	firstMouse = true
+
+	// pendingMouseDX/pendingMouseDY accumulate mouseCallback's per-event
+	// deltas (sensitivity and InvertY already applied) between frames;
+	// see applyPendingMouseLook.
+	pendingMouseDX, pendingMouseDY float32
+
+	// cursorCaptured tracks whether the mouse is locked to the window for
+	// look control (CursorDisabled) or free to leave it (CursorNormal);
+	// toggled by KeyR, see toggleCursorCapture.
+	cursorCaptured = true
+
+	// fbWidth/fbHeight track the live framebuffer size, kept up to date by
+	// framebufferSizeCallback. Used for the viewport and the projection's
+	// aspect ratio, since the framebuffer can differ from the window's
+	// logical size on high-DPI displays and from windowWidth/windowHeight
+	// after any resize.
+	fbWidth, fbHeight int
+
+	grids         []*Grid
+	axes          *Axes
+	axisArrows    *AxisArrows
+	gizmo         *OriginGizmo
+	light         *Light
+	gridHighlight *GridHighlight
+	labels        []*TextLabel
+	demoCube      *WireCube
+	normalViz     *NormalVisualizer
+	model         *Mesh
+
+	// referenceQuad is the -texture image, if any, drawn flat on the
+	// ground plane; see drawSceneCore.
+	referenceQuad *TexturedQuad
+
+	// sceneObjects holds the scene's per-element visibility toggles; see
+	// SceneObjects.
+	sceneObjects *SceneObjects
+
+	// sceneFileObjects are the extra primitives loaded from -scene, if
+	// any; see loadScene.
+	sceneFileObjects []*Object
+
+	// pickMarker is dropped at the last left-clicked ground point and
+	// drawn only until pickMarkerExpiry, so it reads as a transient
+	// "you clicked here" cue rather than a permanent scene object.
+	pickMarker       *WireCube
+	pickMarkerExpiry float64
+
+	// measureTool shows the distance between two ground-plane points
+	// picked while measureModeActive is set (KeyF6 toggles it); see
+	// measure.go.
+	measureTool       *MeasureTool
+	measureModeActive bool
+
+	// viewportLayout toggles between the single-pane view and
+	// drawQuadViewports' perspective+top+front+side split, via the Tab
+	// key (see keyCallback). topCamera/frontCamera/sideCamera are the
+	// fixed orthographic cameras for the three non-perspective panes;
+	// camera itself is reused as the perspective pane.
+	viewportLayout                     ViewportLayout
+	topCamera, frontCamera, sideCamera *Camera
+
+	// matricesUBO is the shared projection/view uniform buffer every
+	// shader's Matrices block reads from; see updateMatricesUBO.
+	matricesUBO uint32
+
+	// showNormals toggles geometry-shader normal visualization. It has no
+	// visible effect until a triangle mesh with per-vertex normals is
+	// drawn through NormalVisualizer.Draw.
+	showNormals bool
+
+	renderStats = NewRenderStats()
+	fpsPlot     *Plot2D
+
+	// hud is the on-screen coordinate readout (camera position/yaw/
+	// pitch/fov/fps), toggled with KeyU. The title bar keeps showing its
+	// own AA/fps/culled summary regardless, as a fallback for when the
+	// overlay is hidden.
+	hud        *HUD
+	hudVisible = true
+
+	// lightView renders the scene from the light's point of view instead
+	// of the camera's, for debugging what a shadow map would see.
+	lightView bool
+
+	postPipeline *PostPipeline
+	aaMode       = AANone
+
+	// invertColors is presentation mode: a final full-screen override that
+	// inverts the resolved frame so the dark-themed scene stays readable
+	// projected in a bright room. It applies after AA resolve and is
+	// independent of aaMode. The repo has no theme system yet to combine
+	// with; this overrides whatever theme exists today.
+	invertColors bool
+
+	// toneMappingEnabled gates ApplyToneMap, the gamma/exposure pass run
+	// after AA resolve (see PostPipeline.ApplyToneMap), same as
+	// invertColors gates ApplyInvert. toneGamma/toneExposure are its
+	// uniforms; 2.2 is the conventional display gamma, and 1.0 exposure
+	// is a no-op linear pre-scale until a scene actually needs brighter/
+	// darker exposure control.
+	toneMappingEnabled = true
+	toneGamma          = float32(2.2)
+	toneExposure       = float32(1.0)
+
+	// Startup fly-in: the camera eases from introFrom to its normal spawn
+	// pose over introDuration seconds instead of snapping straight in.
+	introFrom     = mgl32.Vec3{0, 40, 80}
+	introDuration = 2.0
+	introStart    = -1.0
+
+	// skipIntro bypasses the fly-in lerp entirely, for a pose that should
+	// appear immediately instead of flying in from introFrom (-headless,
+	// or a camera state restored from disk).
+	skipIntro = false
+
+	// smoothedFPS is an exponential moving average of 1/deltaTime, shown
+	// in the title bar; lastTitleUpdate throttles SetTitle to a few times
+	// a second instead of every frame.
+	smoothedFPS     float64
+	lastTitleUpdate float64
+)
+
+// fpsSmoothing is the EMA weight given to each frame's instantaneous
+// FPS; smaller values smooth more aggressively.
+const fpsSmoothing = 0.1
+
+// maxDeltaTime caps deltaTime so a stall — a window drag, a breakpoint,
+// the first frame after setup — can't produce a single giant movement
+// step; see clampDeltaTime.
+const maxDeltaTime = 0.1
+
+// clampDeltaTime clamps dt to [0, maxDeltaTime]. Negative dt shouldn't
+// happen (glfw.GetTime() is monotonic), but zeroing it out rather than
+// trusting that is cheap insurance against moving the camera backwards.
+func clampDeltaTime(dt float64) float64 {
+	if dt < 0 {
+		return 0
+	}
+	if dt > maxDeltaTime {
+		return maxDeltaTime
+	}
+	return dt
+}
+
+// advanceSimulation steps every deltaTime-driven animation by dt: zoom/
+// look easing, the reset animation, and spin. Called once per frame with
+// the real frame dt, or, under -fixed-timestep, zero or more times per
+// frame with a fixed dt - see Run.
+func advanceSimulation(dt float64) {
+	camera.UpdateZoom()
+	camera.UpdateLook()
+	camera.UpdateOrbit()
+	camera.UpdateResetAnimation(float32(dt))
+	updateSpin(dt)
+}
+
+// titleUpdateInterval, in seconds, is how often the title bar's FPS
+// figure refreshes.
+const titleUpdateInterval = 0.5
+
+func init() {
+	// GLFW and OpenGL must run on the thread that created the context.
+	runtime.LockOSThread()
+}
+
+// App owns the window for one run of the viewer and splits main's old
+// monolithic body into Init/Run/Cleanup, so the same setup-loop-teardown
+// code is callable (and testable in pieces) from something other than
+// this package's main. The scene state Init populates - camera,
+// postPipeline, grids, and the rest - stays in the package-level
+// variables the rest of the file has always used, since the GLFW
+// callbacks registered in Init (keyCallback, mouseCallback, ...) read
+// them directly and are plain functions, not methods.
+type App struct {
+	window *glfw.Window
+}
+
+// Init parses flags, creates the window and GL context, and builds every
+// scene resource main used to build inline. It returns an error instead
+// of calling log.Fatal itself, so a caller embedding App can decide how
+// to report a failed startup.
+func (a *App) Init() error {
+	flag.Parse()
+
+	if *widthFlag > 0 {
+		windowWidth = *widthFlag
+	} else {
+		log.Printf("invalid -width %d; using default %d", *widthFlag, defaultWindowWidth)
+	}
+	if *heightFlag > 0 {
+		windowHeight = *heightFlag
+	} else {
+		log.Printf("invalid -height %d; using default %d", *heightFlag, defaultWindowHeight)
+	}
+	if *titleFlag != "" {
+		windowTitle = *titleFlag
+	}
+	lastX = float64(windowWidth) / 2
+	lastY = float64(windowHeight) / 2
+
+	if err := glfw.Init(); err != nil {
+		return fmt.Errorf("glfw init: %w", err)
+	}
+
+	glfw.WindowHint(glfw.Resizable, glfw.True)
+	if *headless || *recordDir != "" || *stressCount > 0 {
+		glfw.WindowHint(glfw.Visible, glfw.False)
+	}
+	msaaSamplesRequested := validMSAASamples(*msaaFlag)
+	glfw.WindowHint(glfw.Samples, msaaSamplesRequested)
+
+	window, err := createWindowWithFallback(windowWidth, windowHeight, windowTitle)
+	if err != nil {
+		glfw.Terminate()
+		return err
+	}
+	a.window = window
+	window.MakeContextCurrent()
+	window.SetCursorPosCallback(mouseCallback)
+	window.SetMouseButtonCallback(mouseButtonCallback)
+	window.SetScrollCallback(scrollCallback)
+	window.SetKeyCallback(keyCallback)
+	window.SetCharCallback(charCallback)
+	window.SetFramebufferSizeCallback(framebufferSizeCallback)
+	window.SetContentScaleCallback(contentScaleCallback)
+	window.SetFocusCallback(focusCallback)
+	if !*headless && *stressCount == 0 {
+		window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	}
+	glfw.SwapInterval(1)
+
+	if err := gl.Init(); err != nil {
+		glfw.Terminate()
+		return fmt.Errorf("gl init: %w", err)
+	}
+	enableGLDebug()
+	if *gpuInfoFlag {
+		if err := logGPUInfo(); err != nil {
+			log.Printf("gpuinfo: %v", err)
+		}
+	}
+
+	lineWidthScale = float32(*linewidthFlag)
+	initLineWidth()
+	if *lineSmoothFlag {
+		gl.Enable(gl.LINE_SMOOTH)
+		gl.Hint(gl.LINE_SMOOTH_HINT, gl.NICEST)
+	} else {
+		gl.Disable(gl.LINE_SMOOTH)
+	}
+	initLineBlend()
+
+	gl.Enable(gl.DEPTH_TEST)
+	if msaaSamplesRequested > 0 {
+		gl.Enable(gl.MULTISAMPLE)
+		var actualSamples int32
+		gl.GetIntegerv(gl.SAMPLES, &actualSamples)
+		if int(actualSamples) != msaaSamplesRequested {
+			log.Printf("requested %d MSAA samples, got %d (driver fallback)", msaaSamplesRequested, actualSamples)
+		}
+	}
+	bg := defaultBGColor
+	if *bgColor != "" {
+		if r, g, b, err := parseHexColor(*bgColor); err != nil {
+			log.Printf("parse -bg: %v; using default", err)
+		} else {
+			bg = [3]float32{r, g, b}
+		}
+	}
+	clearColor = bg
+	gl.ClearColor(bg[0], bg[1], bg[2], 1.0)
+	fogColor = mgl32.Vec3{bg[0], bg[1], bg[2]}
+
+	if *bgTopColor != "" {
+		if r, g, b, err := parseHexColor(*bgTopColor); err != nil {
+			log.Printf("parse -bg-top: %v; gradient background disabled", err)
+		} else {
+			gradientEnabled = true
+			gradientTop = [3]float32{r, g, b}
+			gradientBottom = bg
+			if *bgBottomColor != "" {
+				if r, g, b, err := parseHexColor(*bgBottomColor); err != nil {
+					log.Printf("parse -bg-bottom: %v; using -bg/default", err)
+				} else {
+					gradientBottom = [3]float32{r, g, b}
+				}
+			}
+			initBackground()
+		}
+	}
+
+	matricesUBO = newMatricesUBO()
+
+	camera = NewCamera(introFrom, homeYaw, homePitch, homeFov)
+	camera.QuaternionMode = *quatCameraFlag
+	camera.Sensitivity = float32(*sensitivityFlag)
+	camera.InvertY = *invertYFlag
+	camera.AnimateReset = *animateResetFlag
+	camera.ResetDuration = resetAnimationDuration
+	camera.VerticalLocalUp = *verticalLocalUpFlag
+	camera.MovementFrame = parseMovementFrame(*movementFrameFlag)
+
+	nearPlane = float32(*nearFlag)
+	farPlane = float32(*farFlag)
+	if nearPlane > 0 && farPlane/nearPlane > maxDepthRatio {
+		log.Printf("warning: far/near ratio %.0f exceeds %.0f; expect z-fighting on distant geometry (see -near/-far)", farPlane/nearPlane, maxDepthRatio)
+	}
+
+	if *fovMinFlag < *fovMaxFlag {
+		fovMin = float32(*fovMinFlag)
+		fovMax = float32(*fovMaxFlag)
+	} else {
+		log.Printf("warning: -fov-min (%v) must be less than -fov-max (%v); using defaults %v/%v", *fovMinFlag, *fovMaxFlag, fovMin, fovMax)
+	}
+	camera.Fov = clamp32(camera.Fov, fovMin, fovMax)
+	camera.TargetFov = camera.Fov
+	if *recordDir != "" && *recordFrames <= 0 {
+		return fmt.Errorf("-record requires -frames > 0")
+	}
+	if *headless || *recordDir != "" || *stressCount > 0 {
+		// Skip the fly-in animation: an offscreen render needs a
+		// deterministic starting pose, not whatever introT landed on.
+		camera.Position = spawnPos
+	} else if err := loadCameraState(camera, defaultCameraStatePath); err == nil {
+		// A saved vantage point takes priority over the fly-in intro.
+		skipIntro = true
+	}
+	if *cameraFlag != "" {
+		position, yaw, pitch, err := parseCameraCoords(*cameraFlag)
+		if err != nil {
+			log.Printf("-camera %q: %v", *cameraFlag, err)
+		} else {
+			camera.Reset(position, yaw, pitch, camera.Fov)
+			skipIntro = true
+		}
+	}
+
+	bindings, err := LoadKeyBindings(*keysPath)
+	if err != nil {
+		log.Printf("load key bindings: %v", err)
+	}
+	keyBindings = bindings
+
+	grids = NewGridSet(DefaultGridConfigs)
+	axes = NewAxesWithStyle(5.0, [3]float32{defaultAxisThickness, defaultAxisThickness, defaultAxisThickness}, *axisGradientFlag)
+	axisArrows = NewAxisArrows()
+	gizmo = NewOriginGizmo()
+	sceneObjects = NewSceneObjects()
+	gridHighlight = NewGridHighlight()
+	pickMarker = NewWireCube(mgl32.Vec3{}, 0.25, mgl32.Vec3{1, 0.9, 0.1})
+	pickMarker.Solid = true
+	measureTool = NewMeasureTool()
+
+	// Top/front/side look straight down each axis at the origin, the
+	// same fixed views a modeling tool's quad layout uses. Top's pitch
+	// stops just short of -90 since LookAtV degenerates when Front is
+	// exactly parallel to Up.
+	topCamera = NewOrthoCamera(mgl32.Vec3{0, 10, 0}, -90, -89.9, 8)
+	frontCamera = NewOrthoCamera(mgl32.Vec3{0, 0, 10}, -90, 0, 8)
+	sideCamera = NewOrthoCamera(mgl32.Vec3{10, 0, 0}, 180, 0, 8)
+	labels = []*TextLabel{NewTextLabel("origin", mgl32.Vec3{0.2, 0.2, 0})}
+	axisLabels = newAxisLabels()
+	demoCube = NewWireCube(mgl32.Vec3{3, 0.5, 0}, 1, mgl32.Vec3{0.7, 0.7, 0.8})
+	initSpin()
+	initLetterbox()
+	normalViz = NewNormalVisualizer()
+	fpsPlot = NewPlot2D()
+	hud = NewHUD()
+	crosshair = NewCrosshair()
+	crosshairSize = float32(*crosshairSizeFlag)
+	if *crosshairColorFlag != "" {
+		if r, g, b, err := parseHexColor(*crosshairColorFlag); err != nil {
+			log.Printf("parse -crosshair-color: %v; using default", err)
+		} else {
+			crosshairColor = [3]float32{r, g, b}
+		}
+	}
+	light = NewLight(mgl32.Vec3{10, 15, 10}, mgl32.Vec3{0, 0, 0})
+
+	if *modelPath != "" {
+		m, err := loadOBJ(*modelPath, float32(*weldToleranceFlag))
+		if err != nil {
+			log.Printf("load model %q: %v", *modelPath, err)
+		} else {
+			model = m
+			if *vertPath != "" && *fragPath != "" {
+				if program, err := loadShaderFromFiles(*vertPath, *fragPath); err != nil {
+					log.Printf("load custom shader: %v", err)
+				} else {
+					model.SetProgram(program)
+				}
+			}
+		}
+	}
+
+	if *texturePath != "" {
+		q, err := NewTexturedQuad(*texturePath, mgl32.Vec3{}, float32(*textureSize))
+		if err != nil {
+			log.Printf("load texture %q: %v", *texturePath, err)
+		} else {
+			referenceQuad = q
+		}
+	}
+
+	if *scenePath != "" {
+		objects, err := loadScene(*scenePath)
+		if err != nil {
+			log.Printf("load scene %q: %v", *scenePath, err)
+		} else {
+			sceneFileObjects = objects
+		}
+	}
+
+	fbWidth, fbHeight = window.GetFramebufferSize()
+	contentScaleX, contentScaleY = window.GetContentScale()
+	postPipeline = NewPostPipeline(fbWidth, fbHeight)
+
+	if *stressCount > 0 {
+		spawnStressCubes(*stressCount)
+	}
+
+	return nil
+}
+
+// Run renders the -headless single frame or the -record frame sequence,
+// runs the -stress benchmark, or otherwise drives the main loop until the
+// window is asked to close. Callers must call Init first.
+func (a *App) Run() {
+	window := a.window
+	if *stressCount > 0 {
+		renderStress(window)
+		return
+	}
+	if *headless {
+		renderHeadlessFrame(window)
+		return
+	}
+	if *recordDir != "" {
+		renderRecording(window)
+		return
+	}
+
+	// Seed lastFrame right before the loop starts: glfw.GetTime() already
+	// counts from process start, not from here, so leaving lastFrame at
+	// its zero value would make the first deltaTime include everything
+	// spent on setup in Init and teleport the camera if a movement key is
+	// already held.
+	lastFrame = glfw.GetTime()
+
+	for !window.ShouldClose() {
+		now := glfw.GetTime()
+		deltaTime = clampDeltaTime(now - lastFrame)
+		lastFrame = now
+		renderStats.RecordFrame(deltaTime)
+		if deltaTime > 0 {
+			instFPS := 1.0 / deltaTime
+			if smoothedFPS == 0 {
+				smoothedFPS = instFPS
+			} else {
+				smoothedFPS += (instFPS - smoothedFPS) * fpsSmoothing
+			}
+		}
+		applyPendingMouseLook()
+		if *fixedTimestepFlag {
+			timestepAccumulator += deltaTime
+			for timestepAccumulator >= *timestepFlag {
+				advanceSimulation(*timestepFlag)
+				timestepAccumulator -= *timestepFlag
+			}
+		} else {
+			advanceSimulation(deltaTime)
+		}
+
+		if introStart < 0 {
+			introStart = now
+		}
+		if introT := (now - introStart) / introDuration; !skipIntro && introT < 1 {
+			camera.Position = lerpVec3(introFrom, spawnPos, smoothstep(float32(introT)))
+		} else {
+			processInput(window)
+		}
+
+		postPipeline.Begin(aaMode)
+		gl.Viewport(0, 0, int32(fbWidth), int32(fbHeight))
+
+		vpX, vpY, vpW, vpH := int32(0), int32(0), int32(fbWidth), int32(fbHeight)
+		if letterboxEnabled && !(viewportLayout == LayoutQuad && !lightView) {
+			vpX, vpY, vpW, vpH = letterboxRect(fbWidth, fbHeight, letterboxRatio)
+			clearLetterbox(vpX, vpY, vpW, vpH)
+		} else {
+			gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+		}
+
+		width, height := window.GetSize()
+		curVpX, curVpY, curVpW, curVpH := int32(0), int32(0), int32(fbWidth), int32(fbHeight)
+		if viewportLayout == LayoutQuad && !lightView {
+			drawQuadViewports(fbWidth, fbHeight)
+			gl.Viewport(0, 0, int32(fbWidth), int32(fbHeight))
+		} else {
+			curVpX, curVpY, curVpW, curVpH = vpX, vpY, vpW, vpH
+			gl.Viewport(vpX, vpY, vpW, vpH)
+			var view, projection mgl32.Mat4
+			if lightView {
+				view = light.ViewMatrix()
+				projection = light.ProjectionMatrix()
+			} else {
+				view = camera.ViewMatrix()
+				projection = camera.ProjectionMatrix(aspectRatio(int(vpW), int(vpH)), nearPlane, farPlane)
+				if aaMode == AATAA {
+					jx, jy := postPipeline.TAAJitter()
+					projection = mgl32.Translate3D(jx, jy, 0).Mul4(projection)
+				}
+			}
+
+			updateMatricesUBO(matricesUBO, projection, view)
+			drawScene(window, view, projection, width, height)
+		}
+		checkGLError("drawScene")
+
+		scale := uiScale()
+		fpsPlot.Draw(renderStats.fpsHistory, 144, 10*scale, 10*scale, 160*scale, 40*scale, fbWidth, fbHeight, [3]float32{0.2, 1, 0.4})
+		if hudVisible {
+			hud.Draw(hudLines(), fbWidth, fbHeight, scale)
+		}
+		if !camera.OrbitEnabled && crosshairVisible {
+			crosshair.Draw(fbWidth, fbHeight, crosshairSize*scale, crosshairColor)
+		}
+		gizmo.Draw(camera, fbWidth, fbHeight, curVpX, curVpY, curVpW, curVpH)
+
+		postPipeline.End(aaMode)
+		if toneMappingEnabled {
+			postPipeline.ApplyToneMap(toneGamma, toneExposure)
+		}
+		if invertColors {
+			postPipeline.ApplyInvert()
+		}
+		if now-lastTitleUpdate >= titleUpdateInterval {
+			window.SetTitle(fmt.Sprintf("%s — AA: %s — %.0f fps — culled %d/%d", windowTitle, aaMode, smoothedFPS, culledObjects, drawnObjects+culledObjects))
+			lastTitleUpdate = now
+		}
+
+		window.SwapBuffers()
+
+		if *pauseOnUnfocusFlag && !windowFocused {
+			// Block until input arrives instead of spinning the loop for a
+			// window nobody's looking at. WaitEvents can block for an
+			// arbitrary amount of real time, so lastFrame is reset right
+			// after it returns - otherwise the next deltaTime would include
+			// the entire time spent unfocused, clamped by maxDeltaTime but
+			// still a visible stutter on resume.
+			glfw.WaitEvents()
+			lastFrame = glfw.GetTime()
+			continue
+		}
+		glfw.PollEvents()
+
+		if *maxFPS > 0 {
+			elapsed := glfw.GetTime() - now
+			if target := 1.0 / float64(*maxFPS); elapsed < target {
+				time.Sleep(time.Duration((target - elapsed) * float64(time.Second)))
+			}
+		}
+	}
+}
+
+// Cleanup frees every GL resource registered with resources, tears down
+// the GLFW/GL context, and reports the run's stats and any leaked GL
+// resources. DeleteAll runs in every path, headless or not, since it
+// only needs a live GL context - not a run long enough to have
+// accumulated meaningful stats. The -headless, -record, and -stress
+// paths exit before the render loop's stats have accumulated anything
+// meaningful (-stress prints its own frame time report instead), so
+// they skip that report but still terminate GLFW, matching main's old
+// early return.
+func (a *App) Cleanup() {
+	defer glfw.Terminate()
+	resources.DeleteAll()
+	if *headless || *recordDir != "" || *stressCount > 0 {
+		return
+	}
+	log.Println(renderStats.Summary())
+	if leaks := reportLeaks(); leaks != "" {
+		log.Println(leaks)
+	}
+}
+
+func main() {
+	app := &App{}
+	if err := app.Init(); err != nil {
+		log.Fatal(err)
+	}
+	defer app.Cleanup()
+	app.Run()
+}
+
+// drawSceneCore issues the draw calls common to every pane, single-
+// viewport or split: the gradient background (if -bg-top enabled one),
+// grids, axes, labels, the demo cube, any addPoints scatter data, and
+// the loaded model (frustum-culled against this pane's own
+// view/projection).
+// Callers must have already uploaded view/projection to the shared
+// Matrices UBO (see updateMatricesUBO).
+func drawSceneCore(view, projection mgl32.Mat4) {
+	drawBackground()
+	if sceneObjects.Grid {
+		if *adaptiveGridFlag {
+			drawAdaptiveGrid(camera)
+		} else {
+			for _, g := range grids {
+				g.UpdateFollow(camera.Position, infiniteGrid)
+				g.Draw()
+			}
+		}
+	}
+	if referenceQuad != nil {
+		referenceQuad.Draw()
+	}
+	axes.Visible = [3]bool{sceneObjects.AxisX, sceneObjects.AxisY, sceneObjects.AxisZ}
+	axes.Draw()
+	axisArrows.Draw()
+	drawAxisLabels(camera)
+	updateMatricesUBO(matricesUBO, projection, view)
+	for _, label := range labels {
+		label.Draw(view)
+	}
+	if sceneObjects.Cube {
+		demoCube.Draw()
+	}
+	for _, o := range sceneFileObjects {
+		o.Draw()
+	}
+	for _, pc := range pointClouds {
+		pc.Draw()
+	}
+	for _, pl := range polylines {
+		pl.Draw()
+	}
+	for _, cube := range stressCubes {
+		cube.Draw()
+	}
+	drawnObjects, culledObjects = 0, 0
+	if model != nil {
+		frustum := NewFrustum(projection.Mul4(view))
+		if frustum.Intersects(model.Bounds(), mgl32.Ident4()) {
+			model.Draw(mgl32.Ident4())
+			drawnObjects++
+		} else {
+			culledObjects++
+		}
+	}
+}
+
+// drawScene is drawSceneCore plus the mouse-driven ground picking, used
+// by the single-viewport layout and the headless path; width/height are
+// the window's logical size, used only for the mouse ray. Split-viewport
+// rendering uses drawSceneCore directly per pane instead, since ground
+// picking is only wired up for the single-viewport cursor (see
+// viewportAt).
+func drawScene(window *glfw.Window, view, projection mgl32.Mat4, width, height int) {
+	drawSceneCore(view, projection)
+
+	if !lightView {
+		mouseXPos, mouseYPos := window.GetCursorPos()
+		rayOrigin, rayDir := ScreenPointToRay(mouseXPos, mouseYPos, width, height, view, projection)
+		if hit, ok := IntersectGroundPlane(rayOrigin, rayDir); ok {
+			gridHighlight.Draw(hit, DefaultGridConfigs[0].Spacing)
+		}
+	}
+
+	if glfw.GetTime() < pickMarkerExpiry {
+		pickMarker.Draw()
+	}
+
+	measureTool.Draw(view)
+}
+
+// drawQuadViewports renders the scene once per pane of quadViewports,
+// each with its own gl.Viewport/gl.Scissor rect and camera, so a
+// perspective view and three fixed orthographic views can be compared
+// side by side for alignment work.
+func drawQuadViewports(fbWidth, fbHeight int) {
+	gl.Enable(gl.SCISSOR_TEST)
+	defer gl.Disable(gl.SCISSOR_TEST)
+
+	for _, vp := range quadViewports(camera, topCamera, frontCamera, sideCamera, fbWidth, fbHeight) {
+		gl.Viewport(vp.X, vp.Y, vp.Width, vp.Height)
+		gl.Scissor(vp.X, vp.Y, vp.Width, vp.Height)
+		gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+		view := vp.Camera.ViewMatrix()
+		projection := vp.Camera.ProjectionMatrix(aspectRatio(int(vp.Width), int(vp.Height)), nearPlane, farPlane)
+		updateMatricesUBO(matricesUBO, projection, view)
+		drawSceneCore(view, projection)
+	}
+}
+
+// renderHeadlessFrame draws exactly one frame from the fixed home camera
+// pose and writes it to outPath, for golden-image regression tests on a
+// machine with no display server. fbWidth/fbHeight and the camera pose
+// are both deterministic (see -headless's setup in Init), so the output
+// is reproducible across runs.
+func renderHeadlessFrame(window *glfw.Window) {
+	postPipeline.Begin(aaMode)
+	gl.Viewport(0, 0, int32(fbWidth), int32(fbHeight))
+
+	vpX, vpY, vpW, vpH := int32(0), int32(0), int32(fbWidth), int32(fbHeight)
+	if letterboxEnabled {
+		vpX, vpY, vpW, vpH = letterboxRect(fbWidth, fbHeight, letterboxRatio)
+		clearLetterbox(vpX, vpY, vpW, vpH)
+	} else {
+		gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	}
+	gl.Viewport(vpX, vpY, vpW, vpH)
+
+	view := camera.ViewMatrix()
+	projection := camera.ProjectionMatrix(aspectRatio(int(vpW), int(vpH)), nearPlane, farPlane)
+	updateMatricesUBO(matricesUBO, projection, view)
+	drawScene(window, view, projection, fbWidth, fbHeight)
+
+	postPipeline.End(aaMode)
+	if toneMappingEnabled {
+		postPipeline.ApplyToneMap(toneGamma, toneExposure)
+	}
+	if invertColors {
+		postPipeline.ApplyInvert()
+	}
+
+	if err := captureScreenshot(*outPath); err != nil {
+		log.Fatalf("headless render: %v", err)
+	}
+	log.Printf("wrote headless frame to %s", *outPath)
+}
+
+// recordFPS/recordOrbitDegreesPerSecond define -record's fixed timestep
+// orbit path: recordFrames frames advance recordOrbitDegreesPerSecond /
+// recordFPS degrees of yaw each, regardless of how long each frame
+// actually takes to render, so the output plays back smooth and at the
+// same speed on a fast machine as a slow one - unlike the interactive
+// loop's deltaTime, which is tied to wall-clock time on purpose.
+const (
+	recordFPS                   = 60.0
+	recordOrbitDegreesPerSecond = 15.0
+)
+
+// renderRecording renders recordFrames frames along a slow orbit around
+// the origin, writing each as a numbered PNG to recordDir - e.g.
+// frame-00000.png, frame-00001.png, ... - suitable for assembling into a
+// video with `ffmpeg -framerate 60 -i frame-%05d.png out.mp4`. It
+// repurposes Camera's own turntable orbit (OrbitEnabled/UpdateOrbit,
+// otherwise driven by mouse scroll and WASD) rather than scripting a
+// separate camera path from scratch.
+func renderRecording(window *glfw.Window) {
+	if err := os.MkdirAll(*recordDir, 0o755); err != nil {
+		log.Fatalf("record: create %s: %v", *recordDir, err)
+	}
+
+	camera.OrbitEnabled = true
+	camera.OrbitTarget = mgl32.Vec3{0, 0, 0}
+	camera.OrbitRadius = camera.Position.Sub(camera.OrbitTarget).Len()
+	camera.TargetOrbitRadius = camera.OrbitRadius
+
+	yawStep := float32(recordOrbitDegreesPerSecond / recordFPS)
+
+	for i := 0; i < *recordFrames; i++ {
+		camera.Yaw += yawStep
+		camera.updateVectors()
+		camera.UpdateOrbit()
+
+		postPipeline.Begin(aaMode)
+		gl.Viewport(0, 0, int32(fbWidth), int32(fbHeight))
+
+		vpX, vpY, vpW, vpH := int32(0), int32(0), int32(fbWidth), int32(fbHeight)
+		if letterboxEnabled {
+			vpX, vpY, vpW, vpH = letterboxRect(fbWidth, fbHeight, letterboxRatio)
+			clearLetterbox(vpX, vpY, vpW, vpH)
+		} else {
+			gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+		}
+		gl.Viewport(vpX, vpY, vpW, vpH)
+
+		view := camera.ViewMatrix()
+		projection := camera.ProjectionMatrix(aspectRatio(int(vpW), int(vpH)), nearPlane, farPlane)
+		updateMatricesUBO(matricesUBO, projection, view)
+		drawScene(window, view, projection, fbWidth, fbHeight)
+
+		postPipeline.End(aaMode)
+		if toneMappingEnabled {
+			postPipeline.ApplyToneMap(toneGamma, toneExposure)
+		}
+		if invertColors {
+			postPipeline.ApplyInvert()
+		}
+
+		framePath := filepath.Join(*recordDir, fmt.Sprintf("frame-%05d.png", i))
+		if err := captureScreenshot(framePath); err != nil {
+			log.Fatalf("record frame %d: %v", i, err)
+		}
+		window.SwapBuffers()
+		glfw.PollEvents()
+		log.Printf("recorded frame %d/%d", i+1, *recordFrames)
+	}
+	log.Printf("wrote %d frames to %s", *recordFrames, *recordDir)
+}
+
+// hudLines formats the camera readout HUD shows: position, yaw, pitch,
+// fov, and fps, one per line, the same figures the title bar's fallback
+// summary is built from.
+func hudLines() []string {
+	lines := []string{
+		fmt.Sprintf("pos   %.2f %.2f %.2f", camera.Position.X(), camera.Position.Y(), camera.Position.Z()),
+		fmt.Sprintf("yaw   %.1f", camera.Yaw),
+		fmt.Sprintf("pitch %.1f", camera.Pitch),
+		fmt.Sprintf("fov   %.1f", camera.Fov),
+		fmt.Sprintf("fps   %.0f", smoothedFPS),
+	}
+	if cameraInputActive {
+		lines = append(lines, fmt.Sprintf("goto> %s", cameraInputBuffer))
+	}
+	if consoleActive {
+		lines = append(lines, consoleHistory...)
+		lines = append(lines, fmt.Sprintf("> %s", consoleBuffer))
+	}
+	return lines
+}
+
+// processInput fuses keyboard and gamepad movement input for the same
+// frame: both sources add into the same forward/strafe axes, so a player
+// can nudge with the stick while tapping a key without either input
+// fighting the other.
+func processInput(window *glfw.Window) {
+	if window.GetKey(keyBindings.Quit) == glfw.Press {
+		window.SetShouldClose(true)
+	}
+
+	var forward, strafe, vertical float32
+	if window.GetKey(keyBindings.Forward) == glfw.Press {
+		forward += 1
+	}
+	if window.GetKey(keyBindings.Back) == glfw.Press {
+		forward -= 1
+	}
+	if window.GetKey(keyBindings.Right) == glfw.Press {
+		strafe += 1
+	}
+	if window.GetKey(keyBindings.Left) == glfw.Press {
+		strafe -= 1
+	}
+	if window.GetKey(keyBindings.Up) == glfw.Press {
+		vertical += 1
+	}
+	if window.GetKey(keyBindings.Down) == glfw.Press {
+		vertical -= 1
+	}
+
+	if window.GetKey(glfw.KeyUp) == glfw.Press {
+		camera.Speed = clamp32(camera.Speed+cameraSpeedAdjustRate*float32(deltaTime), minCameraSpeed, maxCameraSpeed)
+	}
+	if window.GetKey(glfw.KeyDown) == glfw.Press {
+		camera.Speed = clamp32(camera.Speed-cameraSpeedAdjustRate*float32(deltaTime), minCameraSpeed, maxCameraSpeed)
+	}
+
+	if camera.QuaternionMode {
+		var roll float32
+		if window.GetKey(glfw.KeyQ) == glfw.Press {
+			roll -= 1
+		}
+		if window.GetKey(glfw.KeyE) == glfw.Press {
+			roll += 1
+		}
+		camera.Roll(roll, float32(deltaTime))
+	}
+
+	joyForward, joyStrafe := joystickMovement()
+	forward = clamp32(forward+joyForward, -1, 1)
+	strafe = clamp32(strafe+joyStrafe, -1, 1)
+	vertical = clamp32(vertical+joystickVertical(), -1, 1)
+
+	switch camera.MovementFrame {
+	case MovementFrameWorld:
+		camera.MoveWorld(forward, strafe, vertical, float32(deltaTime))
+	case MovementFrameTargetPan:
+		camera.PanTarget(forward, strafe, float32(deltaTime))
+	default:
+		if camera.OrbitEnabled {
+			camera.PanTarget(forward, strafe, float32(deltaTime))
+		} else {
+			camera.ProcessMovement(forward, strafe, vertical, float32(deltaTime))
+		}
+	}
+
+	if yaw, pitch := joystickLook(); yaw != 0 || pitch != 0 {
+		camera.ProcessMouse(yaw*joystickLookSensitivity*float32(deltaTime), pitch*joystickLookSensitivity*float32(deltaTime))
+	}
+}
+
+// cameraSpeedAdjustRate is how fast Up/Down ramps camera.Speed, in
+// units/sec per second held - scaled by deltaTime rather than applied
+// as a flat per-frame step, so the adjustment feels the same regardless
+// of frame rate instead of snapping between minCameraSpeed and
+// maxCameraSpeed almost instantly at a high FPS.
+const (
+	cameraSpeedAdjustRate = 2.0
+	minCameraSpeed        = 0.5
+	maxCameraSpeed        = 50.0
+)
+
+// joystickDeadzone ignores small stick drift around center.
+const joystickDeadzone = 0.15
+
+// joystickMovement reads the first gamepad's left stick as a
+// forward/strafe pair, matching the sign convention of the keyboard axes
+// in processInput.
+func joystickMovement() (forward, strafe float32) {
+	if !glfw.Joystick1.Present() {
+		return 0, 0
+	}
+	state := glfw.Joystick1.GetGamepadState()
+	if state == nil {
+		return 0, 0
+	}
+	strafe = applyDeadzone(state.Axes[glfw.AxisLeftX])
+	forward = -applyDeadzone(state.Axes[glfw.AxisLeftY])
+	return forward, strafe
+}
+
+func applyDeadzone(v float32) float32 {
+	if v > -joystickDeadzone && v < joystickDeadzone {
+		return 0
+	}
+	return v
+}
+
+// joystickLookSensitivity is how many degrees per second the right
+// stick turns the camera at full deflection.
+const joystickLookSensitivity = 120.0
+
+// joystickLook reads the first gamepad's right stick as a yaw/pitch
+// pair, matching ProcessMouse's sign convention (positive pitch looks
+// up).
+func joystickLook() (yaw, pitch float32) {
+	if !glfw.Joystick1.Present() {
+		return 0, 0
+	}
+	state := glfw.Joystick1.GetGamepadState()
+	if state == nil {
+		return 0, 0
+	}
+	yaw = applyDeadzone(state.Axes[glfw.AxisRightX])
+	pitch = -applyDeadzone(state.Axes[glfw.AxisRightY])
+	return yaw, pitch
+}
+
+// joystickVertical reads the first gamepad's triggers as a single
+// vertical axis: right trigger rises, left trigger descends. GLFW
+// reports triggers on [-1, 1] (released to fully pressed), so each is
+// rescaled to [0, 1] before the deadzone and the difference are taken.
+func joystickVertical() float32 {
+	if !glfw.Joystick1.Present() {
+		return 0
+	}
+	state := glfw.Joystick1.GetGamepadState()
+	if state == nil {
+		return 0
+	}
+	left := applyDeadzone((state.Axes[glfw.AxisLeftTrigger] + 1) / 2)
+	right := applyDeadzone((state.Axes[glfw.AxisRightTrigger] + 1) / 2)
+	return right - left
+}
+
+// keyCallback handles discrete (edge-triggered) key presses, as opposed
+// to the held-key polling in processInput.
+func keyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if action != glfw.Press {
+		return
+	}
+	if cameraInputActive {
+		switch key {
+		case glfw.KeyEnter:
+			commitCameraInput(camera)
+		case glfw.KeyEscape:
+			cancelCameraInput()
+		case glfw.KeyBackspace:
+			if cameraInputBuffer != "" {
+				cameraInputBuffer = cameraInputBuffer[:len(cameraInputBuffer)-1]
+			}
+		}
+		return
+	}
+	if consoleActive {
+		switch key {
+		case glfw.KeyEnter:
+			commitConsoleCommand()
+		case glfw.KeyEscape, glfw.KeyGraveAccent:
+			toggleConsole()
+		case glfw.KeyBackspace:
+			if consoleBuffer != "" {
+				consoleBuffer = consoleBuffer[:len(consoleBuffer)-1]
+			}
+		}
+		return
+	}
+	switch key {
+	case glfw.KeyGraveAccent:
+		toggleConsole()
+	case glfw.KeyL:
+		lightView = !lightView
+	case glfw.KeyP:
+		aaMode = aaMode.Next()
+	case glfw.KeyO:
+		demoCube.Selected = !demoCube.Selected
+	case glfw.KeyM:
+		demoCube.Solid = !demoCube.Solid
+	case glfw.KeyN:
+		showNormals = !showNormals
+	case glfw.KeyJ:
+		spinPaused = !spinPaused
+	case glfw.KeyY:
+		crosshairVisible = !crosshairVisible
+	case glfw.KeyR:
+		toggleCursorCapture(w)
+	case glfw.KeyPeriod:
+		frameAll(camera)
+	case glfw.KeyComma:
+		toggleXRayMode()
+	case glfw.KeySemicolon:
+		camera.SetPlaneLock(camera.PlaneLock.Next())
+		log.Printf("plane lock: %s", camera.PlaneLock)
+	case glfw.KeyKp1:
+		if mods&glfw.ModControl != 0 {
+			snapToAxisView(camera, AxisViewBack)
+		} else {
+			snapToAxisView(camera, AxisViewFront)
+		}
+	case glfw.KeyKp3:
+		if mods&glfw.ModControl != 0 {
+			snapToAxisView(camera, AxisViewLeft)
+		} else {
+			snapToAxisView(camera, AxisViewRight)
+		}
+	case glfw.KeyKp7:
+		if mods&glfw.ModControl != 0 {
+			snapToAxisView(camera, AxisViewBottom)
+		} else {
+			snapToAxisView(camera, AxisViewTop)
+		}
+	case glfw.KeyG:
+		infiniteGrid = !infiniteGrid
+	case glfw.KeyF6:
+		measureModeActive = !measureModeActive
+		measureTool.Reset()
+		log.Printf("measure mode: %v", measureModeActive)
+	case glfw.Key1:
+		sceneObjects.AxisX = !sceneObjects.AxisX
+	case glfw.Key2:
+		sceneObjects.AxisY = !sceneObjects.AxisY
+	case glfw.Key3:
+		sceneObjects.AxisZ = !sceneObjects.AxisZ
+	case glfw.Key4:
+		sceneObjects.Grid = !sceneObjects.Grid
+	case glfw.Key5:
+		sceneObjects.Cube = !sceneObjects.Cube
+	case glfw.KeyF:
+		fogEnabled = !fogEnabled
+	case glfw.KeyK:
+		toneMappingEnabled = !toneMappingEnabled
+	case glfw.KeyT:
+		camera.WalkMode = !camera.WalkMode
+	case glfw.KeyU:
+		hudVisible = !hudVisible
+	case glfw.KeyI:
+		invertColors = !invertColors
+	case glfw.KeyC:
+		camera.ToggleProjection()
+	case glfw.KeyB:
+		camera.ToggleOrbit()
+	case glfw.KeyZ:
+		camera.CursorZoom = !camera.CursorZoom
+	case glfw.KeyX:
+		camera.LookSmoothing = !camera.LookSmoothing
+	case glfw.KeyTab:
+		viewportLayout = viewportLayout.Next()
+	case glfw.KeyV:
+		vsyncEnabled = !vsyncEnabled
+		if vsyncEnabled {
+			glfw.SwapInterval(1)
+		} else {
+			glfw.SwapInterval(0)
+		}
+	case glfw.KeyF12:
+		if err := captureScreenshot(screenshotPath()); err != nil {
+			log.Printf("screenshot: %v", err)
+		}
+	case glfw.KeyF9:
+		if err := saveCameraState(camera, defaultCameraStatePath); err != nil {
+			log.Printf("save camera state: %v", err)
+		} else {
+			log.Printf("saved camera state to %s", defaultCameraStatePath)
+		}
+	case keyBindings.Reset:
+		camera.StartReset(homePos, homeYaw, homePitch, homeFov)
+	case glfw.KeyF5:
+		reloadModelShader()
+	case glfw.KeyF8:
+		startCameraInput()
+	}
+}
+
+// reloadModelShader recompiles the model's shader from the current
+// -vert/-frag files and swaps it in only if compiling and linking
+// succeed, so a typo in the shader source logs an error and keeps the
+// app running on the previous program rather than crashing it.
+func reloadModelShader() {
+	if model == nil || *vertPath == "" || *fragPath == "" {
+		return
+	}
+	program, err := loadShaderFromFiles(*vertPath, *fragPath)
+	if err != nil {
+		log.Printf("reload shader: %v", err)
+		return
+	}
+	model.SetProgram(program)
+	log.Println("reloaded model shader")
+}
+
+// framebufferSizeCallback keeps fbWidth/fbHeight and the post-processing
+// framebuffers in sync with the window whenever it's resized.
+func framebufferSizeCallback(w *glfw.Window, width, height int) {
+	fbWidth, fbHeight = width, height
+	if postPipeline != nil {
+		postPipeline.Resize(width, height)
+	}
+}
+
+// aspectRatio returns width/height, falling back to a square aspect if
+// height is 0 (e.g. the window is minimized) rather than dividing by
+// zero and feeding NaN/Inf into the projection matrix.
+func aspectRatio(width, height int) float32 {
+	if height == 0 {
+		return 1
+	}
+	return float32(width) / float32(height)
+}
+
+// validMSAASamples snaps n to the nearest supported window-framebuffer
+// sample count (0, 2, 4, 8), logging a warning when it had to. 0 and
+// negative values disable multisampling outright rather than rounding
+// up, since "off" is a deliberate choice callers should be able to make.
+func validMSAASamples(n int) int {
+	switch n {
+	case 0, 2, 4, 8:
+		return n
+	}
+	if n <= 0 {
+		return 0
+	}
+	valid := []int{2, 4, 8}
+	best := valid[0]
+	for _, v := range valid {
+		if abs(n-v) < abs(n-best) {
+			best = v
+		}
+	}
+	log.Printf("invalid -msaa %d; using %d", n, best)
+	return best
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// toggleCursorCapture flips the mouse between locked (CursorDisabled,
+// for look control) and free (CursorNormal), bound to KeyR. firstMouse
+// is set and the pending look accumulator cleared on every transition,
+// not just on release, so the next mouseCallback event after a toggle
+// never reads as one big jump - whether capturing (the OS can warp the
+// cursor back to center) or releasing (the cursor may already have
+// drifted far from its last captured position).
+func toggleCursorCapture(window *glfw.Window) {
+	cursorCaptured = !cursorCaptured
+	if cursorCaptured {
+		window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	} else {
+		window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	}
+	firstMouse = true
+	pendingMouseDX, pendingMouseDY = 0, 0
+}
+
+// computeMouseDelta converts a raw OS mouse-move event into a look
+// delta, applying sensitivity and InvertY exactly as mouseCallback does.
+// Split out so the math is testable without a real GLFW window.
+func computeMouseDelta(xpos, ypos, lastX, lastY float64, sensitivity float32, invertY bool) (dx, dy float32) {
+	dx = float32(xpos-lastX) * sensitivity
+	dy = float32(lastY-ypos) * sensitivity
+	if invertY {
+		dy = -dy
+	}
+	return dx, dy
+}
+
+// mouseCallback fires once per OS mouse-move event - possibly several
+// times between frames on a fast mouse or high polling rate, or not at
+// all on a slow one - so it only accumulates the event's delta into
+// pendingMouseDX/pendingMouseDY rather than feeding it to the camera
+// directly; applyPendingMouseLook drains the accumulator once per frame
+// in the render loop, decoupling look speed from event frequency.
+// Sensitivity and InvertY are applied here, per event, exactly as
+// before. While the cursor is released (see toggleCursorCapture) moves
+// are tracked but not turned into look input, so the camera doesn't
+// jump once the cursor is recaptured.
+func mouseCallback(w *glfw.Window, xpos, ypos float64) {
+	if !cursorCaptured {
+		lastX, lastY = xpos, ypos
+		return
+	}
+	if firstMouse {
+		lastX, lastY = xpos, ypos
+		firstMouse = false
+	}
+	xoffset, yoffset := computeMouseDelta(xpos, ypos, lastX, lastY, camera.Sensitivity, camera.InvertY)
+	lastX, lastY = xpos, ypos
+
+	pendingMouseDX += xoffset
+	pendingMouseDY += yoffset
+}
+
+// applyPendingMouseLook feeds the mouse delta accumulated since the last
+// frame into the camera once per frame, then resets the accumulator.
+// See mouseCallback for why the accumulation happens instead of calling
+// ProcessMouse directly from the callback.
+func applyPendingMouseLook() {
+	camera.ProcessMouse(pendingMouseDX, pendingMouseDY)
+	pendingMouseDX, pendingMouseDY = 0, 0
+}
+
+// activeViewportCamera returns the camera whose pane the cursor is over,
+// so scroll/click input affects only that pane (see drawQuadViewports).
+// In the single-viewport layout it's always the main camera.
+func activeViewportCamera(w *glfw.Window) *Camera {
+	if viewportLayout != LayoutQuad {
+		return camera
+	}
+	width, height := w.GetSize()
+	mouseX, mouseY := w.GetCursorPos()
+	// Cursor coordinates are in the window's logical space; the panes
+	// are laid out in framebuffer pixels, which can differ on a
+	// high-DPI display.
+	fbX := mouseX * float64(fbWidth) / float64(width)
+	fbY := mouseY * float64(fbHeight) / float64(height)
+	viewports := quadViewports(camera, topCamera, frontCamera, sideCamera, fbWidth, fbHeight)
+	if vp := viewportAt(viewports, fbX, fbY, fbHeight); vp != nil {
+		return vp.Camera
+	}
+	return camera
+}
+
+// pickMarkerDuration is how long, in seconds, the ground marker from a
+// left-click pick stays visible before fading out of drawScene.
+const pickMarkerDuration = 1.0
+
+// mouseButtonCallback casts a ray through the cursor on left-click,
+// prints the point where it crosses the ground plane, and drops
+// pickMarker there for pickMarkerDuration.
+func mouseButtonCallback(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	if button != glfw.MouseButtonLeft || action != glfw.Press {
+		return
+	}
+	width, height := w.GetSize()
+	mouseX, mouseY := w.GetCursorPos()
+	cam := activeViewportCamera(w)
+	view := cam.ViewMatrix()
+	projection := cam.ProjectionMatrix(aspectRatio(fbWidth, fbHeight), nearPlane, farPlane)
+	rayOrigin, rayDir := ScreenPointToRay(mouseX, mouseY, width, height, view, projection)
+	hit, ok := IntersectGroundPlane(rayOrigin, rayDir)
+	if !ok {
+		return
+	}
+
+	if measureModeActive {
+		measureTool.AddPoint(hit)
+		return
+	}
+
+	log.Printf("picked ground point %v", hit)
+	pickMarker.Position = hit
+	pickMarkerExpiry = glfw.GetTime() + pickMarkerDuration
+}
+
+func scrollCallback(w *glfw.Window, xoff, yoff float64) {
+	width, height := w.GetSize()
+	mouseX, mouseY := w.GetCursorPos()
+	cam := activeViewportCamera(w)
+	view := cam.ViewMatrix()
+	projection := cam.ProjectionMatrix(aspectRatio(fbWidth, fbHeight), nearPlane, farPlane)
+	rayOrigin, rayDir := ScreenPointToRay(mouseX, mouseY, width, height, view, projection)
+	cam.ProcessScrollAt(float32(yoff), rayOrigin, rayDir)
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestClampDeltaTime(t *testing.T) {
+	cases := []struct {
+		in, want float64
+	}{
+		{-1, 0},
+		{0, 0},
+		{0.016, 0.016},
+		{maxDeltaTime, maxDeltaTime},
+		{5, maxDeltaTime},
+	}
+	for _, c := range cases {
+		if got := clampDeltaTime(c.in); got != c.want {
+			t.Errorf("clampDeltaTime(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestComputeMouseDelta(t *testing.T) {
+	cases := []struct {
+		name                     string
+		xpos, ypos, lastX, lastY float64
+		sensitivity              float32
+		invertY                  bool
+		wantDX, wantDY           float32
+	}{
+		{"no movement", 400, 300, 400, 300, 0.1, false, 0, 0},
+		{"move right and up", 410, 290, 400, 300, 0.1, false, 1, 1},
+		{"invertY flips vertical", 410, 290, 400, 300, 0.1, true, 1, -1},
+		{"sensitivity scales both axes", 420, 320, 400, 300, 0.5, false, 10, -10},
+	}
+	for _, c := range cases {
+		dx, dy := computeMouseDelta(c.xpos, c.ypos, c.lastX, c.lastY, c.sensitivity, c.invertY)
+		if dx != c.wantDX || dy != c.wantDY {
+			t.Errorf("%s: computeMouseDelta() = (%v, %v), want (%v, %v)", c.name, dx, dy, c.wantDX, c.wantDY)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func cos32(x float32) float32 { return float32(math.Cos(float64(x))) }
+func sin32(x float32) float32 { return float32(math.Sin(float64(x))) }
+func tan32(x float32) float32 { return float32(math.Tan(float64(x))) }
+
+// smoothstep eases t (clamped to [0,1]) with a cubic Hermite curve, for
+// animations that should start and end at zero velocity.
+func smoothstep(t float32) float32 {
+	if t < 0 {
+		t = 0
+	}
+	if t > 1 {
+		t = 1
+	}
+	return t * t * (3 - 2*t)
+}
+
+func lerpVec3(a, b mgl32.Vec3, t float32) mgl32.Vec3 {
+	return a.Add(b.Sub(a).Mul(t))
+}
+
+func clamp32(v, min, max float32) float32 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// boolToInt32 converts b to 0 or 1, for passing bool-shaped state to a
+// GL uniform via gl.Uniform1i (GLSL bool uniforms take an int).
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func minf(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxf(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// measureLineColor is the line MeasureTool draws between its two points.
+var measureLineColor = mgl32.Vec3{1, 1, 0.2}
+
+// MeasureTool lets a user click two points (on the ground plane, or on
+// geometry once picking grows to support that) and shows the Euclidean
+// distance between them as a line plus a billboard text label at the
+// midpoint. Points accumulate via AddPoint; a third click starts a new
+// measurement rather than extending the old one. See measureModeActive
+// and the KeyF6 toggle in keyCallback.
+type MeasureTool struct {
+	program      uint32
+	vao, vbo     uint32
+	modelUniform int32
+	colorUniform int32
+
+	points    [2]mgl32.Vec3
+	numPoints int
+	label     *TextLabel
+}
+
+// NewMeasureTool compiles the line's shader (reusing WireCube/sceneLine's
+// outlineVertexShader/outlineFragmentShader, since this is likewise a
+// flat-colored, uniform-driven line) and allocates its vertex buffer;
+// AddPoint uploads fresh endpoints once two points are picked.
+func NewMeasureTool() *MeasureTool {
+	program, err := newProgram(outlineVertexShader, outlineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	var vao, vbo uint32
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.VertexAttribPointerWithOffset(0, 3, gl.FLOAT, false, 3*4, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.BindVertexArray(0)
+
+	return &MeasureTool{
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("color\x00")),
+	}
+}
+
+// AddPoint records p as the next measurement point. A third call after
+// a completed pair starts over at p rather than accumulating a third
+// point, so measuring again just means clicking twice more.
+func (m *MeasureTool) AddPoint(p mgl32.Vec3) {
+	if m.numPoints == 2 {
+		m.numPoints = 0
+	}
+	m.points[m.numPoints] = p
+	m.numPoints++
+	if m.numPoints != 2 {
+		return
+	}
+
+	vertices := []float32{
+		m.points[0].X(), m.points[0].Y(), m.points[0].Z(),
+		m.points[1].X(), m.points[1].Y(), m.points[1].Z(),
+	}
+	gl.BindBuffer(gl.ARRAY_BUFFER, m.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, safeGLPtr(vertices), gl.DYNAMIC_DRAW)
+
+	distance := m.points[0].Sub(m.points[1]).Len()
+	midpoint := m.points[0].Add(m.points[1]).Mul(0.5)
+	if m.label != nil {
+		m.label.Delete()
+	}
+	m.label = NewTextLabel(fmt.Sprintf("%.3f", distance), midpoint)
+	m.label.Billboard = true
+	m.label.Color = measureLineColor
+}
+
+// Reset clears the current measurement so Draw stops rendering it.
+func (m *MeasureTool) Reset() {
+	m.numPoints = 0
+	if m.label != nil {
+		m.label.Delete()
+		m.label = nil
+	}
+}
+
+// Draw renders the measurement line and its distance label, if a pair
+// of points has been picked. view is passed straight through to the
+// label, which needs it on the CPU side for billboarding.
+func (m *MeasureTool) Draw(view mgl32.Mat4) {
+	if m.numPoints != 2 {
+		return
+	}
+
+	identity := mgl32.Ident4()
+	gl.UseProgram(m.program)
+	gl.UniformMatrix4fv(m.modelUniform, 1, false, &identity[0])
+	gl.Uniform4f(m.colorUniform, measureLineColor.X(), measureLineColor.Y(), measureLineColor.Z(), 1)
+
+	blending := beginLineBlend()
+	gl.BindVertexArray(m.vao)
+	setLineWidth(2)
+	gl.DrawArrays(gl.LINES, 0, 2)
+	gl.BindVertexArray(0)
+	setLineWidth(1)
+	endLineBlend(blending)
+
+	m.label.Draw(view)
+}
+
+// Delete frees the tool's GL resources, including its label if any.
+func (m *MeasureTool) Delete() {
+	if m.label != nil {
+		m.label.Delete()
+	}
+	gl.DeleteVertexArrays(1, &m.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &m.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(m.program)
+	trackDelete("program")
+}
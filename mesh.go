@@ -0,0 +1,328 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Mesh is a triangle list of interleaved position+normal vertex data
+// (6 floats per vertex), lit with a single fixed directional light so
+// loaded OBJ models read as solid shapes rather than flat silhouettes.
+type Mesh struct {
+	vao, vbo    uint32
+	program     uint32
+	vertexCount int32
+
+	modelUniform int32
+
+	// instancedProgram/instanceVBO back DrawInstanced: instanceVBO holds
+	// one mat4 per instance, read by instancedMeshVertexShader from
+	// attribute slots 3-6 (one vec4 per matrix column) instead of the
+	// model uniform, advanced one instance at a time via
+	// gl.VertexAttribDivisor. Built alongside the regular program/vbo so
+	// a mesh is always ready for either draw path without a first-use
+	// stall.
+	instancedProgram uint32
+	instanceVBO      uint32
+
+	// bounds is the mesh's local-space AABB, computed once at
+	// construction so the render loop can frustum-cull it without
+	// re-scanning vertex data every frame.
+	bounds AABB
+}
+
+// NewMesh uploads interleaved position+normal vertices and compiles the
+// mesh shader. vertices may be empty (an OBJ with no faces draws nothing).
+func NewMesh(vertices []float32) *Mesh {
+	program, err := newProgram(meshVertexShader, meshFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	instancedProgram, err := newProgram(instancedMeshVertexShader, meshFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	var vao, vbo, instanceVBO uint32
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	resources.Register(ResourceVAO, vao)
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+	resources.Register(ResourceBuffer, vbo)
+	gl.GenBuffers(1, &instanceVBO)
+	trackCreate("buffer")
+	resources.Register(ResourceBuffer, instanceVBO)
+
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, safeGLPtr(vertices), gl.STATIC_DRAW)
+
+	const stride = 6 * 4
+	gl.VertexAttribPointerWithOffset(0, 3, gl.FLOAT, false, stride, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(1, 3, gl.FLOAT, false, stride, 3*4)
+	gl.EnableVertexAttribArray(1)
+
+	// Attribute slots 3-6 hold one column of the instance's model matrix
+	// each (slot 2 is left free in case a future vertex format adds a
+	// third per-vertex attribute, e.g. UVs). The divisor of 1 advances
+	// these once per instance instead of once per vertex, which is what
+	// lets gl.DrawArraysInstanced read a different matrix per copy.
+	gl.BindBuffer(gl.ARRAY_BUFFER, instanceVBO)
+	const matStride = 16 * 4
+	for i := 0; i < 4; i++ {
+		loc := uint32(3 + i)
+		gl.VertexAttribPointerWithOffset(loc, 4, gl.FLOAT, false, matStride, i*4*4)
+		gl.EnableVertexAttribArray(loc)
+		gl.VertexAttribDivisor(loc, 1)
+	}
+
+	gl.BindVertexArray(0)
+
+	return &Mesh{
+		vao:              vao,
+		vbo:              vbo,
+		program:          program,
+		vertexCount:      int32(len(vertices) / 6),
+		modelUniform:     gl.GetUniformLocation(program, gl.Str("model\x00")),
+		instancedProgram: instancedProgram,
+		instanceVBO:      instanceVBO,
+		bounds:           NewAABB(vertices, 6),
+	}
+}
+
+// Bounds returns the mesh's local-space bounding box, for frustum
+// culling by the caller.
+func (m *Mesh) Bounds() AABB {
+	return m.bounds
+}
+
+// SetProgram replaces the mesh's shader program, e.g. with one loaded
+// from external files via loadShaderFromFiles, re-resolving its uniform
+// locations and deleting the program it replaces.
+func (m *Mesh) SetProgram(program uint32) {
+	gl.DeleteProgram(m.program)
+	trackDelete("program")
+	m.program = program
+	m.modelUniform = gl.GetUniformLocation(program, gl.Str("model\x00"))
+}
+
+// Draw renders the mesh with the given model matrix. view/projection
+// come from the shared Matrices UBO (see updateMatricesUBO).
+func (m *Mesh) Draw(model mgl32.Mat4) {
+	if m.vertexCount == 0 {
+		return
+	}
+	gl.UseProgram(m.program)
+	gl.UniformMatrix4fv(m.modelUniform, 1, false, &model[0])
+
+	gl.BindVertexArray(m.vao)
+	gl.DrawArrays(gl.TRIANGLES, 0, m.vertexCount)
+	gl.BindVertexArray(0)
+}
+
+// DrawInstanced renders one copy of the mesh per transform in a single
+// gl.DrawArraysInstanced call, with each copy's model matrix read from
+// instanceVBO instead of a uniform - the path to take for thousands of
+// repeated objects (e.g. a voxel grid), where issuing one Draw call per
+// copy spends far more time on driver call overhead than on the GPU
+// work itself. view/projection come from the shared Matrices UBO (see
+// updateMatricesUBO), same as Draw.
+func (m *Mesh) DrawInstanced(transforms []mgl32.Mat4) {
+	if m.vertexCount == 0 || len(transforms) == 0 {
+		return
+	}
+	data := flattenInstanceTransforms(transforms)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, m.instanceVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(data)*4, safeGLPtr(data), gl.STREAM_DRAW)
+
+	gl.UseProgram(m.instancedProgram)
+	gl.BindVertexArray(m.vao)
+	gl.DrawArraysInstanced(gl.TRIANGLES, 0, m.vertexCount, int32(len(transforms)))
+	gl.BindVertexArray(0)
+}
+
+// flattenInstanceTransforms packs transforms into the column-major
+// float layout instanceVBO expects: 16 floats per instance, one mat4
+// each. Split out from DrawInstanced so the CPU-side packing cost can be
+// measured on its own (see BenchmarkFlattenInstanceTransforms10k) without
+// needing a live GL context.
+func flattenInstanceTransforms(transforms []mgl32.Mat4) []float32 {
+	data := make([]float32, 0, len(transforms)*16)
+	for _, t := range transforms {
+		data = append(data, t[:]...)
+	}
+	return data
+}
+
+// IndexedMesh draws position-only geometry through an index buffer, so
+// vertices shared by multiple edges/faces - a cube's 8 corners, for
+// example - upload once instead of once per edge/face that touches
+// them. It draws with WireCube's flat-color shader
+// (outlineVertexShader/outlineFragmentShader, wirecube.go) rather than
+// the lit meshVertexShader/meshFragmentShader above, since the geometry
+// sparse enough to benefit from sharing vertices like this (wireframes,
+// simple shapes) doesn't carry per-vertex normals; wiring normal
+// sharing through an indexed *lit* mesh for OBJ models is a natural
+// next step but not attempted here.
+type IndexedMesh struct {
+	vao, vbo, ebo uint32
+	program       uint32
+	modelUniform  int32
+	colorUniform  int32
+	indexCount    int32
+	primitive     uint32
+}
+
+// NewIndexedMesh uploads position-only vertices (3 floats each) and a
+// uint32 index buffer, drawn with gl.DrawElements using the given
+// primitive (e.g. gl.LINES for a wireframe, gl.TRIANGLES for a filled
+// shape).
+func NewIndexedMesh(vertices []float32, indices []uint32, primitive uint32) *IndexedMesh {
+	program, err := newProgram(outlineVertexShader, outlineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	var vao, vbo, ebo uint32
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	resources.Register(ResourceVAO, vao)
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+	resources.Register(ResourceBuffer, vbo)
+	gl.GenBuffers(1, &ebo)
+	trackCreate("buffer")
+	resources.Register(ResourceBuffer, ebo)
+
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, safeGLPtr(vertices), gl.STATIC_DRAW)
+	gl.VertexAttribPointerWithOffset(0, 3, gl.FLOAT, false, 3*4, 0)
+	gl.EnableVertexAttribArray(0)
+
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, ebo)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, safeGLPtr(indices), gl.STATIC_DRAW)
+
+	gl.BindVertexArray(0)
+
+	return &IndexedMesh{
+		vao:          vao,
+		vbo:          vbo,
+		ebo:          ebo,
+		program:      program,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("color\x00")),
+		indexCount:   int32(len(indices)),
+		primitive:    primitive,
+	}
+}
+
+// Draw renders the mesh with the given model matrix and flat color.
+// view/projection come from the shared Matrices UBO (see
+// updateMatricesUBO), same as Mesh.Draw. Blending (see beginLineBlend) is
+// only enabled for the gl.LINES primitive - a wireframe like WireCube's
+// edges - since -line-blend-func additive would double-brighten a filled
+// shape drawn with gl.TRIANGLES, which NewIndexedMesh also supports.
+func (m *IndexedMesh) Draw(model mgl32.Mat4, color mgl32.Vec4) {
+	blending := m.primitive == gl.LINES && beginLineBlend()
+	gl.UseProgram(m.program)
+	gl.UniformMatrix4fv(m.modelUniform, 1, false, &model[0])
+	gl.Uniform4f(m.colorUniform, color.X(), color.Y(), color.Z(), color.W())
+	gl.BindVertexArray(m.vao)
+	gl.DrawElements(m.primitive, m.indexCount, gl.UNSIGNED_INT, nil)
+	gl.BindVertexArray(0)
+	endLineBlend(blending)
+}
+
+// Delete frees the mesh's GL resources.
+func (m *IndexedMesh) Delete() {
+	gl.DeleteVertexArrays(1, &m.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &m.vbo)
+	trackDelete("buffer")
+	gl.DeleteBuffers(1, &m.ebo)
+	trackDelete("buffer")
+	gl.DeleteProgram(m.program)
+	trackDelete("program")
+}
+
+// Delete frees the mesh's GL resources.
+func (m *Mesh) Delete() {
+	gl.DeleteVertexArrays(1, &m.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &m.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(m.program)
+	trackDelete("program")
+
+	gl.DeleteBuffers(1, &m.instanceVBO)
+	trackDelete("buffer")
+	gl.DeleteProgram(m.instancedProgram)
+	trackDelete("program")
+}
+
+const meshVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec3 aNormal;
+
+uniform mat4 model;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+out vec3 vNormal;
+
+void main() {
+	gl_Position = projection * view * model * vec4(aPos, 1.0);
+	vNormal = mat3(model) * aNormal;
+}
+` + "\x00"
+
+// instancedMeshVertexShader is meshVertexShader's DrawInstanced
+// counterpart: instead of a single model uniform, it reconstructs each
+// copy's model matrix from 4 per-instance vec4 attributes (one per
+// column), supplied by instanceVBO and advanced once per instance via
+// gl.VertexAttribDivisor rather than once per vertex.
+const instancedMeshVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec3 aNormal;
+layout (location = 3) in vec4 aModelCol0;
+layout (location = 4) in vec4 aModelCol1;
+layout (location = 5) in vec4 aModelCol2;
+layout (location = 6) in vec4 aModelCol3;
+
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+out vec3 vNormal;
+
+void main() {
+	mat4 model = mat4(aModelCol0, aModelCol1, aModelCol2, aModelCol3);
+	gl_Position = projection * view * model * vec4(aPos, 1.0);
+	vNormal = mat3(model) * aNormal;
+}
+` + "\x00"
+
+// meshFragmentShader shades with a single fixed directional light, just
+// enough to read loaded models as solid shapes rather than flat
+// silhouettes.
+const meshFragmentShader = `
+#version 460 core
+in vec3 vNormal;
+out vec4 FragColor;
+
+void main() {
+	vec3 lightDir = normalize(vec3(0.4, 0.8, 0.4));
+	float diffuse = max(dot(normalize(vNormal), lightDir), 0.0);
+	vec3 color = vec3(0.6, 0.65, 0.75) * (0.3 + 0.7 * diffuse);
+	FragColor = vec4(color, 1.0);
+}
+` + "\x00"
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestFlattenInstanceTransformsPacksColumnMajor(t *testing.T) {
+	transforms := []mgl32.Mat4{
+		mgl32.Translate3D(1, 2, 3),
+		mgl32.Ident4(),
+	}
+
+	data := flattenInstanceTransforms(transforms)
+	if len(data) != 32 {
+		t.Fatalf("len(data) = %d, want 32 (2 instances * 16 floats)", len(data))
+	}
+	if data[12] != 1 || data[13] != 2 || data[14] != 3 {
+		t.Errorf("first instance's translation column = %v, want [1 2 3 1]", data[12:16])
+	}
+	if data[16+15] != 1 {
+		t.Errorf("second instance's matrix not packed at offset 16, data[31] = %v, want 1", data[31])
+	}
+}
+
+// BenchmarkFlattenInstanceTransforms10k measures the CPU-side cost of
+// packing 10k instances into the float buffer DrawInstanced uploads -
+// the part of the instanced path that can be benchmarked without a live
+// GL context. It's the preparation step a naive per-instance Draw loop
+// skips entirely (each iteration just sets a uniform), so this number is
+// the instanced path's added CPU overhead versus the naive baseline, not
+// a full draw-call timing comparison.
+func BenchmarkFlattenInstanceTransforms10k(b *testing.B) {
+	transforms := make([]mgl32.Mat4, 10000)
+	for i := range transforms {
+		transforms[i] = mgl32.Translate3D(float32(i), 0, 0)
+	}
+
+	for i := 0; i < b.N; i++ {
+		flattenInstanceTransforms(transforms)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// degenerateAreaEpsilon is the minimum triangle area (in squared cross
+// product magnitude) below which a triangle is considered degenerate.
+const degenerateAreaEpsilon = 1e-12
+
+// triangleIsDegenerate reports whether the triangle formed by the three
+// given positions has (effectively) zero area, which produces NaN
+// normals and lighting artifacts if rendered as-is.
+func triangleIsDegenerate(a, b, c mgl32.Vec3) bool {
+	cross := b.Sub(a).Cross(c.Sub(a))
+	return cross.Dot(cross) < degenerateAreaEpsilon
+}
+
+// WeldVertices merges positions that lie within tolerance of each other,
+// returning the deduplicated positions and a remap table from old index
+// to new index. A tolerance of 0 disables welding (remap is the identity).
+func WeldVertices(positions []mgl32.Vec3, tolerance float32) (welded []mgl32.Vec3, remap []int) {
+	remap = make([]int, len(positions))
+	if tolerance <= 0 {
+		welded = append(welded, positions...)
+		for i := range remap {
+			remap[i] = i
+		}
+		return welded, remap
+	}
+
+	tolSq := tolerance * tolerance
+	for i, p := range positions {
+		found := -1
+		for j, w := range welded {
+			d := p.Sub(w)
+			if d.Dot(d) <= tolSq {
+				found = j
+				break
+			}
+		}
+		if found >= 0 {
+			remap[i] = found
+			continue
+		}
+		remap[i] = len(welded)
+		welded = append(welded, p)
+	}
+	return welded, remap
+}
+
+// CleanTriangles welds near-duplicate vertices within weldTolerance (set
+// to 0 to skip welding) and drops degenerate zero-area triangles. It
+// returns the cleaned positions, the cleaned triangle index list, and the
+// number of degenerate triangles that were dropped, so callers can report
+// how messy an imported mesh was.
+func CleanTriangles(positions []mgl32.Vec3, indices []uint32, weldTolerance float32) (cleaned []mgl32.Vec3, cleanedIndices []uint32, degenerateCount int) {
+	cleaned, remap := WeldVertices(positions, weldTolerance)
+
+	for i := 0; i+2 < len(indices); i += 3 {
+		ia := remap[indices[i]]
+		ib := remap[indices[i+1]]
+		ic := remap[indices[i+2]]
+		if ia == ib || ib == ic || ia == ic || triangleIsDegenerate(cleaned[ia], cleaned[ib], cleaned[ic]) {
+			degenerateCount++
+			continue
+		}
+		cleanedIndices = append(cleanedIndices, uint32(ia), uint32(ib), uint32(ic))
+	}
+	return cleaned, cleanedIndices, degenerateCount
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestCleanTrianglesDropsDegenerate(t *testing.T) {
+	positions := []mgl32.Vec3{
+		{0, 0, 0},
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 0}, // degenerate: collinear with the first triangle's edge
+		{2, 0, 0},
+		{0, 0, 0},
+	}
+	indices := []uint32{0, 1, 2, 3, 4, 5}
+
+	_, cleanedIndices, degenerate := CleanTriangles(positions, indices, 0)
+	if degenerate != 1 {
+		t.Errorf("degenerate count = %d, want 1", degenerate)
+	}
+	if len(cleanedIndices) != 3 {
+		t.Errorf("cleanedIndices = %v, want one surviving triangle", cleanedIndices)
+	}
+}
+
+func TestWeldVerticesMergesWithinTolerance(t *testing.T) {
+	positions := []mgl32.Vec3{
+		{0, 0, 0},
+		{0.001, 0, 0},
+		{5, 0, 0},
+	}
+	welded, remap := WeldVertices(positions, 0.01)
+
+	if len(welded) != 2 {
+		t.Fatalf("welded = %v, want 2 unique positions", welded)
+	}
+	if remap[0] != remap[1] {
+		t.Errorf("remap[0]=%d remap[1]=%d, want them merged", remap[0], remap[1])
+	}
+	if remap[2] == remap[0] {
+		t.Errorf("remap[2] should not merge with the distant vertex")
+	}
+}
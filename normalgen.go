@@ -0,0 +1,72 @@
+package main
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// computeNormals derives smooth per-vertex normals from triangle
+// geometry, for meshes (like an OBJ with no "vn" directives) that don't
+// carry their own. positions is a flat x,y,z-per-vertex array and
+// indices is a flat triangle list (three indices per triangle, into
+// positions); the result is the same per-vertex layout as positions,
+// one normal per vertex.
+//
+// Each triangle's unnormalized face normal (its cross product, whose
+// length is proportional to the triangle's area) is accumulated into
+// all three of its corners before normalizing, so a vertex shared by
+// several faces ends up with an area-weighted average rather than a
+// flat per-face normal — smooth-ish shading instead of a faceted look.
+func computeNormals(positions []float32, indices []uint32) []float32 {
+	vertexCount := len(positions) / 3
+	accum := make([]mgl32.Vec3, vertexCount)
+
+	vertexAt := func(i uint32) mgl32.Vec3 {
+		return mgl32.Vec3{positions[3*i], positions[3*i+1], positions[3*i+2]}
+	}
+
+	for t := 0; t+2 < len(indices); t += 3 {
+		a, b, c := indices[t], indices[t+1], indices[t+2]
+		edge1 := vertexAt(b).Sub(vertexAt(a))
+		edge2 := vertexAt(c).Sub(vertexAt(a))
+		faceNormal := edge1.Cross(edge2)
+		accum[a] = accum[a].Add(faceNormal)
+		accum[b] = accum[b].Add(faceNormal)
+		accum[c] = accum[c].Add(faceNormal)
+	}
+
+	normals := make([]float32, len(positions))
+	for i, n := range accum {
+		if n.Len() > 0 {
+			n = n.Normalize()
+		}
+		normals[3*i], normals[3*i+1], normals[3*i+2] = n.X(), n.Y(), n.Z()
+	}
+	return normals
+}
+
+// flattenVec3 and unflattenVec3 convert between a []mgl32.Vec3 and the
+// flat x,y,z-per-vertex layout computeNormals expects and returns.
+func flattenVec3(vs []mgl32.Vec3) []float32 {
+	flat := make([]float32, 0, len(vs)*3)
+	for _, v := range vs {
+		flat = append(flat, v.X(), v.Y(), v.Z())
+	}
+	return flat
+}
+
+func unflattenVec3(flat []float32) []mgl32.Vec3 {
+	vs := make([]mgl32.Vec3, len(flat)/3)
+	for i := range vs {
+		vs[i] = mgl32.Vec3{flat[3*i], flat[3*i+1], flat[3*i+2]}
+	}
+	return vs
+}
+
+// triangleIndices converts a flat triangle-corner index list (already
+// 0-based, as produced by loadOBJ's fan triangulation) to the uint32
+// slice computeNormals expects.
+func triangleIndices(idx []int) []uint32 {
+	out := make([]uint32, len(idx))
+	for i, v := range idx {
+		out[i] = uint32(v)
+	}
+	return out
+}
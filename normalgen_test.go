@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestComputeNormalsSingleTriangle(t *testing.T) {
+	positions := []float32{
+		0, 0, 0,
+		1, 0, 0,
+		0, 1, 0,
+	}
+	indices := []uint32{0, 1, 2}
+
+	normals := computeNormals(positions, indices)
+	if len(normals) != len(positions) {
+		t.Fatalf("len(normals) = %d, want %d", len(normals), len(positions))
+	}
+	for v := 0; v < 3; v++ {
+		nx, ny, nz := normals[3*v], normals[3*v+1], normals[3*v+2]
+		if nx != 0 || ny != 0 || nz <= 0 {
+			t.Errorf("vertex %d normal = (%v, %v, %v), want (0, 0, +)", v, nx, ny, nz)
+		}
+	}
+}
+
+func TestComputeNormalsAveragesSharedVertex(t *testing.T) {
+	// Two coplanar triangles in the XY plane sharing vertex 0; its
+	// normal should still come out as the same flat (0, 0, 1) as a
+	// single triangle would produce, not skewed by double-counting.
+	positions := []float32{
+		0, 0, 0,
+		1, 0, 0,
+		0, 1, 0,
+		-1, 0, 0,
+	}
+	indices := []uint32{0, 1, 2, 0, 2, 3}
+
+	normals := computeNormals(positions, indices)
+	nx, ny, nz := normals[0], normals[1], normals[2]
+	if diff := nz - 1; diff > 1e-4 || diff < -1e-4 || nx != 0 || ny != 0 {
+		t.Errorf("shared vertex normal = (%v, %v, %v), want (0, 0, 1)", nx, ny, nz)
+	}
+}
+
+func TestComputeNormalsDegenerateTriangleStaysZero(t *testing.T) {
+	positions := []float32{
+		0, 0, 0,
+		0, 0, 0,
+		0, 0, 0,
+	}
+	indices := []uint32{0, 1, 2}
+
+	normals := computeNormals(positions, indices)
+	for i, n := range normals {
+		if n != 0 {
+			t.Errorf("normals[%d] = %v, want 0 for a zero-area triangle", i, n)
+		}
+	}
+}
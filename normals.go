@@ -0,0 +1,105 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// normalVisualizationLength is how long, in world units, each drawn
+// normal line is.
+const normalVisualizationLength = 0.2
+
+// NormalVisualizer draws a short line along each vertex normal of a
+// triangle mesh using a geometry shader, so faceless/flat-shaded meshes
+// can be debugged without baked-in normal arrows. It expects interleaved
+// (position, normal) vertex data and is meant to be driven by a Mesh's
+// raw buffers once the mesh pipeline lands.
+type NormalVisualizer struct {
+	program      uint32
+	modelUniform int32
+	lenUniform   int32
+}
+
+// NewNormalVisualizer compiles the vertex/geometry/fragment program used
+// to draw normal lines.
+func NewNormalVisualizer() *NormalVisualizer {
+	program, err := newGeometryProgram(normalVertexShader, normalGeometryShader, normalFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	return &NormalVisualizer{
+		program:      program,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		lenUniform:   gl.GetUniformLocation(program, gl.Str("normalLength\x00")),
+	}
+}
+
+// Draw expects vao to already be bound to interleaved (vec3 position,
+// vec3 normal) attributes at locations 0 and 1, drawn as vertexCount
+// points (one per mesh vertex). view/projection come from the shared
+// Matrices UBO (see updateMatricesUBO).
+func (n *NormalVisualizer) Draw(model mgl32.Mat4, vao uint32, vertexCount int32) {
+	gl.UseProgram(n.program)
+	gl.UniformMatrix4fv(n.modelUniform, 1, false, &model[0])
+	gl.Uniform1f(n.lenUniform, normalVisualizationLength)
+
+	gl.BindVertexArray(vao)
+	gl.DrawArrays(gl.POINTS, 0, vertexCount)
+	gl.BindVertexArray(0)
+}
+
+// Delete frees the visualizer's GL resources.
+func (n *NormalVisualizer) Delete() {
+	gl.DeleteProgram(n.program)
+	trackDelete("program")
+}
+
+const normalVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec3 aNormal;
+
+uniform mat4 model;
+
+out vec3 vNormal;
+
+void main() {
+	gl_Position = model * vec4(aPos, 1.0);
+	vNormal = mat3(transpose(inverse(model))) * aNormal;
+}
+` + "\x00"
+
+const normalGeometryShader = `
+#version 460 core
+layout (points) in;
+layout (line_strip, max_vertices = 2) out;
+
+in vec3 vNormal[];
+
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+uniform float normalLength;
+
+void main() {
+	vec4 origin = projection * view * gl_in[0].gl_Position;
+	gl_Position = origin;
+	EmitVertex();
+
+	vec4 tip = projection * view * (gl_in[0].gl_Position + vec4(vNormal[0] * normalLength, 0.0));
+	gl_Position = tip;
+	EmitVertex();
+
+	EndPrimitive();
+}
+` + "\x00"
+
+const normalFragmentShader = `
+#version 460 core
+out vec4 FragColor;
+
+void main() {
+	FragColor = vec4(1.0, 1.0, 0.0, 1.0);
+}
+` + "\x00"
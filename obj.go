@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// loadOBJ parses a Wavefront OBJ file's "v", "vn", and "f" directives
+// into interleaved position+normal vertex data (6 floats per vertex:
+// x, y, z, nx, ny, nz) and returns a Mesh ready to draw. Faces with more
+// than three vertices are triangulated with a simple fan from the
+// first vertex; any other directive ("vt", "usemtl", "o", comments,
+// ...) is skipped rather than rejected, since this viewer only cares
+// about geometry.
+//
+// weldTolerance is passed to CleanTriangles to merge near-duplicate "v"
+// positions (0 disables welding); degenerate zero-area triangles are
+// always dropped, and the number dropped is logged.
+func loadOBJ(path string, weldTolerance float32) (*Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open obj: %w", err)
+	}
+	defer f.Close()
+
+	var positions []mgl32.Vec3
+	var normals []mgl32.Vec3
+	// vertIdx/normIdx record, per triangle corner in emission order, the
+	// position/normal index it came from, so normals can be filled in
+	// after the fact (see the no-vn branch below) without re-scanning
+	// the file.
+	var vertIdx, normIdx []int
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			v, err := parseVec3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("parse vertex: %w", err)
+			}
+			positions = append(positions, v)
+		case "vn":
+			n, err := parseVec3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("parse normal: %w", err)
+			}
+			normals = append(normals, n)
+		case "f":
+			faceVerts, faceNormals, err := parseFace(fields[1:], len(positions), len(normals))
+			if err != nil {
+				return nil, fmt.Errorf("parse face: %w", err)
+			}
+			for i := range faceVerts {
+				if faceVerts[i] < 0 || faceVerts[i] >= len(positions) {
+					return nil, fmt.Errorf("face vertex index %d out of range", faceVerts[i]+1)
+				}
+			}
+			// Fan-triangulate: (0,1,2), (0,2,3), (0,3,4), ...
+			for i := 1; i+1 < len(faceVerts); i++ {
+				tri := [3]int{0, i, i + 1}
+				for _, t := range tri {
+					vertIdx = append(vertIdx, faceVerts[t])
+					normIdx = append(normIdx, faceNormals[t])
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read obj: %w", err)
+	}
+
+	hasFileNormals := len(normals) > 0
+
+	// Degenerate triangles are always dropped; welding near-duplicate
+	// positions would also remap the surviving corners' indices, which
+	// would desync them from the file's own per-corner "vn" index
+	// (normIdx, below), so only weld when there's no authored normal
+	// data to preserve.
+	cleanWeld := weldTolerance
+	if hasFileNormals {
+		cleanWeld = 0
+	}
+	cleanedPositions, cleanedIndices, degenerateCount := CleanTriangles(positions, triangleIndices(vertIdx), cleanWeld)
+	if degenerateCount > 0 {
+		log.Printf("load obj %q: dropped %d degenerate triangle(s)", path, degenerateCount)
+	}
+
+	if !hasFileNormals {
+		// No "vn" directives anywhere in the file: derive per-vertex
+		// normals from the cleaned face geometry instead of leaving
+		// every normal zero.
+		if len(cleanedPositions) > 0 {
+			normals = unflattenVec3(computeNormals(flattenVec3(cleanedPositions), cleanedIndices))
+		}
+
+		vertices := make([]float32, 0, len(cleanedIndices)*6)
+		for _, pi := range cleanedIndices {
+			pos := cleanedPositions[pi]
+			normal := normals[pi]
+			vertices = append(vertices,
+				pos.X(), pos.Y(), pos.Z(),
+				normal.X(), normal.Y(), normal.Z())
+		}
+		return NewMesh(vertices), nil
+	}
+
+	// hasFileNormals: cleanWeld was 0 above, so cleanedPositions is
+	// positions unchanged and CleanTriangles only dropped degenerate
+	// triangles - walk the original corners ourselves, applying the
+	// same degenerate test, so each surviving corner keeps its own
+	// file-supplied normIdx.
+	vertices := make([]float32, 0, len(vertIdx)*6)
+	for i := 0; i+2 < len(vertIdx); i += 3 {
+		ia, ib, ic := vertIdx[i], vertIdx[i+1], vertIdx[i+2]
+		if ia == ib || ib == ic || ia == ic || triangleIsDegenerate(positions[ia], positions[ib], positions[ic]) {
+			continue
+		}
+		for _, corner := range [3]int{i, i + 1, i + 2} {
+			vi := vertIdx[corner]
+			pos := positions[vi]
+			var normal mgl32.Vec3
+			if ni := normIdx[corner]; ni >= 0 && ni < len(normals) {
+				normal = normals[ni]
+			} else if vi < len(normals) {
+				normal = normals[vi]
+			}
+			vertices = append(vertices,
+				pos.X(), pos.Y(), pos.Z(),
+				normal.X(), normal.Y(), normal.Z())
+		}
+	}
+
+	return NewMesh(vertices), nil
+}
+
+func parseVec3(fields []string) (mgl32.Vec3, error) {
+	if len(fields) < 3 {
+		return mgl32.Vec3{}, fmt.Errorf("want 3 components, got %d", len(fields))
+	}
+	var v mgl32.Vec3
+	for i := 0; i < 3; i++ {
+		f, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return mgl32.Vec3{}, err
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
+// parseFace splits "v/vt/vn" (or "v//vn", or bare "v") tokens into
+// zero-based vertex and normal indices, resolving OBJ's negative
+// (relative-to-end) indices against the current vertex/normal counts.
+// A missing normal index is reported as -1.
+func parseFace(tokens []string, numPositions, numNormals int) (verts, norms []int, err error) {
+	for _, tok := range tokens {
+		parts := strings.Split(tok, "/")
+		vi, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		verts = append(verts, resolveOBJIndex(vi, numPositions))
+
+		ni := -1
+		if len(parts) == 3 && parts[2] != "" {
+			ni, err = strconv.Atoi(parts[2])
+			if err != nil {
+				return nil, nil, err
+			}
+			ni = resolveOBJIndex(ni, numNormals)
+		}
+		norms = append(norms, ni)
+	}
+	return verts, norms, nil
+}
+
+// resolveOBJIndex converts a 1-based OBJ index, or a negative index
+// counting back from the end of the list seen so far, into a 0-based one.
+func resolveOBJIndex(idx, count int) int {
+	if idx < 0 {
+		return count + idx
+	}
+	return idx - 1
+}
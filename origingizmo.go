@@ -0,0 +1,123 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// gizmoViewportSize/gizmoMargin size and place the origin gizmo's own
+// small square viewport in the screen's top-right corner, matching
+// Blender's navigation gizmo.
+const (
+	gizmoViewportSize = int32(90)
+	gizmoMargin       = int32(10)
+)
+
+// gizmoDistance is how far back the gizmo's fixed camera sits from the
+// origin it always looks at - just far enough that gizmoAxisLength-long
+// triad lines fit comfortably inside its fov regardless of where the
+// main camera actually is in the scene.
+const gizmoDistance = float32(3.0)
+
+// gizmoAxisLength is each triad line's length in the gizmo's own local
+// space, unrelated to axisArrowLength (the full-size scene axes).
+const gizmoAxisLength = float32(1.0)
+
+// gizmoAxisTransforms rotate a unit line along local +Z (see
+// gizmoLineVerts) onto each world axis - the same rotations
+// axisArrowTransforms uses for the full-size axis cones, minus their
+// translation (the gizmo's lines start at its own origin).
+var gizmoAxisTransforms = [3]mgl32.Mat4{
+	mgl32.HomogRotate3D(mgl32.DegToRad(90), mgl32.Vec3{0, 1, 0}),
+	mgl32.HomogRotate3D(mgl32.DegToRad(-90), mgl32.Vec3{1, 0, 0}),
+	mgl32.Ident4(),
+}
+
+// gizmoLineVerts is a single unit-length line along local +Z, reused for
+// all three axes via gizmoAxisTransforms.
+var gizmoLineVerts = []float32{0, 0, 0, 0, 0, 1}
+
+// OriginGizmo draws a small XYZ triad fixed in the screen's top-right
+// corner, in its own small viewport, oriented to match the main
+// camera's current rotation but not its position - it's a fixed-
+// distance view of the origin from the camera's current orientation,
+// so it reads as "which way is the camera facing" independent of where
+// in the scene it actually is. Always visible, with no toggle yet.
+// Clicking an axis to snap the main camera to that view is left for
+// later - this is just the always-visible display.
+type OriginGizmo struct {
+	program      uint32
+	vao, vbo     uint32
+	modelUniform int32
+	colorUniform int32
+}
+
+// NewOriginGizmo compiles the gizmo's shader (reusing WireCube's flat-
+// color outline shader, which already takes exactly the model/color
+// uniforms a plain line needs) and uploads its single shared line
+// segment.
+func NewOriginGizmo() *OriginGizmo {
+	program, err := newProgram(outlineVertexShader, outlineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	vao, vbo := newInterleavedBuffer(gizmoLineVerts, 3)
+
+	return &OriginGizmo{
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("color\x00")),
+	}
+}
+
+// Draw renders the triad into its own viewport in the framebuffer's
+// top-right corner, then restores the viewport to restoreX/Y/W/H (the
+// caller's current viewport) so later draws - notably the post-
+// pipeline's full-screen FXAA/TAA passes - aren't left pointed at the
+// gizmo's tiny rect. Does nothing if the framebuffer is too small for
+// the gizmo to fit.
+func (g *OriginGizmo) Draw(camera *Camera, fbWidth, fbHeight int, restoreX, restoreY, restoreW, restoreH int32) {
+	x := int32(fbWidth) - gizmoViewportSize - gizmoMargin
+	y := int32(fbHeight) - gizmoViewportSize - gizmoMargin
+	if x < 0 || y < 0 {
+		return
+	}
+
+	gl.Viewport(x, y, gizmoViewportSize, gizmoViewportSize)
+	gl.Disable(gl.DEPTH_TEST)
+
+	eye := camera.Front.Mul(-gizmoDistance)
+	view := mgl32.LookAtV(eye, mgl32.Vec3{0, 0, 0}, camera.Up)
+	projection := mgl32.Perspective(mgl32.DegToRad(45), 1, 0.1, gizmoDistance*2)
+	updateMatricesUBO(matricesUBO, projection, view)
+
+	blending := beginLineBlend()
+	gl.UseProgram(g.program)
+	gl.BindVertexArray(g.vao)
+	setLineWidth(2)
+	for i, rot := range gizmoAxisTransforms {
+		model := rot.Mul4(mgl32.Scale3D(gizmoAxisLength, gizmoAxisLength, gizmoAxisLength))
+		gl.UniformMatrix4fv(g.modelUniform, 1, false, &model[0])
+		c := axisArrowColors[i]
+		gl.Uniform4f(g.colorUniform, c.X(), c.Y(), c.Z(), 1)
+		gl.DrawArrays(gl.LINES, 0, 2)
+	}
+	setLineWidth(1)
+	gl.BindVertexArray(0)
+	endLineBlend(blending)
+
+	gl.Enable(gl.DEPTH_TEST)
+	gl.Viewport(restoreX, restoreY, restoreW, restoreH)
+}
+
+// Delete frees the gizmo's GL resources.
+func (g *OriginGizmo) Delete() {
+	gl.DeleteVertexArrays(1, &g.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &g.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(g.program)
+	trackDelete("program")
+}
@@ -0,0 +1,15 @@
+package main
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// windowFocused tracks the window's current focus state, kept in sync
+// by focusCallback. Only consulted when -pause-on-unfocus is set; see
+// Run's main loop.
+var windowFocused = true
+
+// focusCallback records focus changes for -pause-on-unfocus. Run resets
+// lastFrame itself right after waking from glfw.WaitEvents, so this
+// doesn't need to do anything beyond tracking the flag.
+func focusCallback(w *glfw.Window, focused bool) {
+	windowFocused = focused
+}
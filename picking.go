@@ -0,0 +1,40 @@
+package main
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// ScreenPointToRay unprojects a window-space point (origin top-left, as
+// reported by GLFW cursor callbacks) into a world-space ray, given the
+// active camera matrices and viewport size.
+func ScreenPointToRay(mouseX, mouseY float64, width, height int, view, projection mgl32.Mat4) (origin, dir mgl32.Vec3) {
+	ndcX := float32(2*mouseX/float64(width) - 1)
+	ndcY := float32(1 - 2*mouseY/float64(height))
+
+	invVP := projection.Mul4(view).Inv()
+
+	near := unproject(invVP, ndcX, ndcY, -1)
+	far := unproject(invVP, ndcX, ndcY, 1)
+
+	origin = near
+	dir = far.Sub(near).Normalize()
+	return origin, dir
+}
+
+func unproject(invVP mgl32.Mat4, x, y, z float32) mgl32.Vec3 {
+	clip := mgl32.Vec4{x, y, z, 1}
+	world := invVP.Mul4x1(clip)
+	return mgl32.Vec3{world.X() / world.W(), world.Y() / world.W(), world.Z() / world.W()}
+}
+
+// IntersectGroundPlane returns the point where the ray (origin, dir)
+// crosses the y=0 plane, and whether that intersection lies in front of
+// the ray's origin.
+func IntersectGroundPlane(origin, dir mgl32.Vec3) (mgl32.Vec3, bool) {
+	if dir.Y() == 0 {
+		return mgl32.Vec3{}, false
+	}
+	t := -origin.Y() / dir.Y()
+	if t < 0 {
+		return mgl32.Vec3{}, false
+	}
+	return origin.Add(dir.Mul(t)), true
+}
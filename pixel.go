@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// ReadPixel returns the RGBA color of the pixel at the given window
+// coordinate (logical, top-left origin) in the current framebuffer. It
+// accounts for the window's content scale (DPI) and OpenGL's bottom-left
+// framebuffer origin, so callers can pass the same coordinates they'd get
+// from a mouse or picking callback. This underpins golden-image
+// assertions and color-picker tooling.
+func ReadPixel(window *glfw.Window, x, y int) (r, g, b, a uint8) {
+	scaleX, scaleY := window.GetContentScale()
+	_, fbHeight := window.GetFramebufferSize()
+
+	fbX := int32(float32(x) * scaleX)
+	fbY := int32(float32(fbHeight-1) - float32(y)*scaleY)
+
+	var pixel [4]uint8
+	gl.ReadPixels(fbX, fbY, 1, 1, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(&pixel))
+	return pixel[0], pixel[1], pixel[2], pixel[3]
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// TestReadPixel clears the whole framebuffer to red and asserts that the
+// pixel at the window's center, and one on its top row, come back red.
+func TestReadPixel(t *testing.T) {
+	runtime.LockOSThread()
+
+	if err := glfw.Init(); err != nil {
+		t.Skipf("glfw init: %v (no display available)", err)
+	}
+	defer glfw.Terminate()
+
+	glfw.WindowHint(glfw.ContextVersionMajor, 4)
+	glfw.WindowHint(glfw.ContextVersionMinor, 6)
+	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	glfw.WindowHint(glfw.Visible, glfw.False)
+
+	window, err := glfw.CreateWindow(64, 64, "pixel-test", nil, nil)
+	if err != nil {
+		t.Skipf("create window: %v (no display available)", err)
+	}
+	window.MakeContextCurrent()
+
+	if err := gl.Init(); err != nil {
+		t.Fatalf("gl init: %v", err)
+	}
+
+	gl.ClearColor(1, 0, 0, 1)
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+
+	r, g, b, _ := ReadPixel(window, 32, 32)
+	if r != 255 || g != 0 || b != 0 {
+		t.Errorf("ReadPixel(32,32) = (%d,%d,%d), want (255,0,0)", r, g, b)
+	}
+
+	// The top row (y=0) is the edge case for the framebuffer Y-flip: it
+	// must map to the last valid framebuffer row, not one past it.
+	r, g, b, _ = ReadPixel(window, 32, 0)
+	if r != 255 || g != 0 || b != 0 {
+		t.Errorf("ReadPixel(32,0) = (%d,%d,%d), want (255,0,0)", r, g, b)
+	}
+}
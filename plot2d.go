@@ -0,0 +1,105 @@
+package main
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// Plot2D draws a simple line chart as a screen-space overlay (e.g. an FPS
+// history graph in a corner of the window), independent of the 3D
+// scene's camera.
+type Plot2D struct {
+	program           uint32
+	vao, vbo          uint32
+	resolutionUniform int32
+	colorUniform      int32
+}
+
+// NewPlot2D compiles the overlay's shader program.
+func NewPlot2D() *Plot2D {
+	program, err := newProgram(plot2DVertexShader, plot2DFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	var vao, vbo uint32
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, 2*4, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.BindVertexArray(0)
+
+	return &Plot2D{
+		program:           program,
+		vao:               vao,
+		vbo:               vbo,
+		resolutionUniform: gl.GetUniformLocation(program, gl.Str("resolution\x00")),
+		colorUniform:      gl.GetUniformLocation(program, gl.Str("color\x00")),
+	}
+}
+
+// Draw plots values (assumed to lie within [0, max]) as a polyline inside
+// the pixel rect (x, y, w, h), with y measured from the top of the
+// window, matching GLFW's screen-coordinate convention.
+func (p *Plot2D) Draw(values []float32, max float32, x, y, w, h float32, screenW, screenH int, color [3]float32) {
+	if len(values) < 2 || max <= 0 {
+		return
+	}
+
+	vertices := make([]float32, 0, len(values)*2)
+	step := w / float32(len(values)-1)
+	for i, v := range values {
+		px := x + float32(i)*step
+		py := y + h - clamp32(v/max, 0, 1)*h
+		vertices = append(vertices, px, py)
+	}
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, p.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, safeGLPtr(vertices), gl.DYNAMIC_DRAW)
+
+	gl.UseProgram(p.program)
+	gl.Uniform2f(p.resolutionUniform, float32(screenW), float32(screenH))
+	gl.Uniform3f(p.colorUniform, color[0], color[1], color[2])
+
+	gl.Disable(gl.DEPTH_TEST)
+	gl.BindVertexArray(p.vao)
+	gl.DrawArrays(gl.LINE_STRIP, 0, int32(len(values)))
+	gl.BindVertexArray(0)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+// Delete frees the overlay's GL resources.
+func (p *Plot2D) Delete() {
+	gl.DeleteVertexArrays(1, &p.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &p.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(p.program)
+	trackDelete("program")
+}
+
+const plot2DVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 aPixelPos;
+
+uniform vec2 resolution;
+
+void main() {
+	vec2 ndc = vec2(
+		(aPixelPos.x / resolution.x) * 2.0 - 1.0,
+		1.0 - (aPixelPos.y / resolution.y) * 2.0
+	);
+	gl_Position = vec4(ndc, 0.0, 1.0);
+}
+` + "\x00"
+
+const plot2DFragmentShader = `
+#version 460 core
+out vec4 FragColor;
+uniform vec3 color;
+
+void main() {
+	FragColor = vec4(color, 1.0);
+}
+` + "\x00"
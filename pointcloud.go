@@ -0,0 +1,140 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// pointClouds are the scatter-plot point sets added via addPoints,
+// drawn by drawSceneCore alongside every other scene object.
+var pointClouds []*PointCloud
+
+// addPoints uploads points as a new PointCloud, rendered as round dots
+// size screen pixels wide in color, appends it to pointClouds so the
+// render loop picks it up, and returns it so a caller that wants to
+// update Color/Size or Delete it later can hold onto the result.
+func addPoints(points []mgl32.Vec3, color mgl32.Vec3, size float32) *PointCloud {
+	cloud := NewPointCloud(points, color, size)
+	pointClouds = append(pointClouds, cloud)
+	return cloud
+}
+
+// PointCloud renders a set of world-space points as billboarded round
+// dots of a constant size in screen pixels, so a caller can plot 3D
+// scatter data directly in the scene's coordinate system. Each point is
+// a single gl.POINTS vertex with gl_PointSize set in pointVertexShader,
+// rather than a quad-per-point billboard, so a cloud of thousands of
+// points is one draw call instead of thousands of quads.
+type PointCloud struct {
+	program      uint32
+	vao, vbo     uint32
+	vertexCount  int32
+	modelUniform int32
+	colorUniform int32
+	sizeUniform  int32
+
+	Color mgl32.Vec3
+
+	// Size is each point's diameter in screen pixels, read every Draw by
+	// pointVertexShader via gl_PointSize - not baked into the vertex
+	// buffer, so changing it doesn't require re-uploading points.
+	Size float32
+}
+
+// NewPointCloud uploads points (position-only, 3 floats each) and
+// compiles the point shader. color and size are shared by every point in
+// the cloud; a caller that wants per-point color or size should build
+// more than one PointCloud.
+func NewPointCloud(points []mgl32.Vec3, color mgl32.Vec3, size float32) *PointCloud {
+	vertices := make([]float32, 0, len(points)*3)
+	for _, p := range points {
+		vertices = append(vertices, p.X(), p.Y(), p.Z())
+	}
+
+	program, err := newProgram(pointVertexShader, pointFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	vao, vbo := newInterleavedBuffer(vertices, 3)
+
+	return &PointCloud{
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		vertexCount:  int32(len(points)),
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("color\x00")),
+		sizeUniform:  gl.GetUniformLocation(program, gl.Str("pointSize\x00")),
+		Color:        color,
+		Size:         size,
+	}
+}
+
+// Draw renders the cloud as round points of Size screen pixels each.
+// gl.PROGRAM_POINT_SIZE must be enabled for gl_PointSize to take effect
+// at all - by default GL ignores it and draws every point at a fixed
+// 1px - so Draw enables it around the draw call rather than relying on
+// some other caller having turned it on globally. view/projection come
+// from the shared Matrices UBO (see updateMatricesUBO).
+func (p *PointCloud) Draw() {
+	if p.vertexCount == 0 {
+		return
+	}
+	identity := mgl32.Ident4()
+	gl.Enable(gl.PROGRAM_POINT_SIZE)
+	gl.UseProgram(p.program)
+	gl.UniformMatrix4fv(p.modelUniform, 1, false, &identity[0])
+	gl.Uniform4f(p.colorUniform, p.Color.X(), p.Color.Y(), p.Color.Z(), 1)
+	gl.Uniform1f(p.sizeUniform, p.Size)
+
+	gl.BindVertexArray(p.vao)
+	gl.DrawArrays(gl.POINTS, 0, p.vertexCount)
+	gl.BindVertexArray(0)
+	gl.Disable(gl.PROGRAM_POINT_SIZE)
+}
+
+// Delete frees the point cloud's GL resources.
+func (p *PointCloud) Delete() {
+	gl.DeleteVertexArrays(1, &p.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &p.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(p.program)
+	trackDelete("program")
+}
+
+const pointVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+
+uniform mat4 model;
+uniform float pointSize;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+void main() {
+	gl_Position = projection * view * model * vec4(aPos, 1.0);
+	gl_PointSize = pointSize;
+}
+` + "\x00"
+
+// pointFragmentShader discards fragments outside a circle so gl.POINTS
+// read as round dots rather than square ones - GL's own point
+// rasterization is square by default, and core profile has no
+// GL_POINT_SMOOTH equivalent to round it for us.
+const pointFragmentShader = `
+#version 460 core
+out vec4 FragColor;
+uniform vec4 color;
+
+void main() {
+	vec2 centered = gl_PointCoord - vec2(0.5);
+	if (dot(centered, centered) > 0.25) {
+		discard;
+	}
+	FragColor = color;
+}
+` + "\x00"
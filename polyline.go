@@ -0,0 +1,94 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// polylines are the connected point sequences added via addPolyline,
+// drawn by drawSceneCore alongside every other scene object.
+var polylines []*Polyline
+
+// addPolyline uploads points as a new Polyline, rendered as a connected
+// line strip in color, appends it to polylines so the render loop picks
+// it up, and returns it so a caller that wants to Delete it later can
+// hold onto the result.
+func addPolyline(points []mgl32.Vec3, color mgl32.Vec3) *Polyline {
+	line := NewPolyline(points, color)
+	polylines = append(polylines, line)
+	return line
+}
+
+// Polyline renders a connected sequence of world-space points as a
+// single flat-colored gl.LINE_STRIP, e.g. a plotted trajectory, orbit,
+// or parametric curve, in the scene's own coordinate system. Unlike
+// sceneLine/MeasureTool's fixed two-point segment, it holds whatever
+// point count the caller passed in at construction.
+type Polyline struct {
+	program      uint32
+	vao, vbo     uint32
+	modelUniform int32
+	colorUniform int32
+	vertexCount  int32
+
+	Color mgl32.Vec3
+}
+
+// NewPolyline uploads points (position-only, 3 floats each) and compiles
+// the shared flat-color line shader (reusing WireCube/sceneLine/
+// MeasureTool's outlineVertexShader/outlineFragmentShader, since this is
+// likewise just a flat-colored, uniform-driven line).
+func NewPolyline(points []mgl32.Vec3, color mgl32.Vec3) *Polyline {
+	vertices := make([]float32, 0, len(points)*3)
+	for _, p := range points {
+		vertices = append(vertices, p.X(), p.Y(), p.Z())
+	}
+
+	program, err := newProgram(outlineVertexShader, outlineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	vao, vbo := newInterleavedBuffer(vertices, 3)
+
+	return &Polyline{
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("color\x00")),
+		vertexCount:  int32(len(points)),
+		Color:        color,
+	}
+}
+
+// Draw renders the line strip at the identity transform - points are
+// expected to already be in world space, the same convention as
+// sceneLine. view/projection come from the shared Matrices UBO (see
+// updateMatricesUBO).
+func (l *Polyline) Draw() {
+	if l.vertexCount < 2 {
+		return
+	}
+	identity := mgl32.Ident4()
+	gl.UseProgram(l.program)
+	gl.UniformMatrix4fv(l.modelUniform, 1, false, &identity[0])
+	gl.Uniform4f(l.colorUniform, l.Color.X(), l.Color.Y(), l.Color.Z(), 1)
+
+	blending := beginLineBlend()
+	gl.BindVertexArray(l.vao)
+	setLineWidth(1)
+	gl.DrawArrays(gl.LINE_STRIP, 0, l.vertexCount)
+	gl.BindVertexArray(0)
+	endLineBlend(blending)
+}
+
+// Delete frees the polyline's GL resources.
+func (l *Polyline) Delete() {
+	gl.DeleteVertexArrays(1, &l.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &l.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(l.program)
+	trackDelete("program")
+}
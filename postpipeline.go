@@ -0,0 +1,522 @@
+package main
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// AAMode selects the anti-aliasing strategy used for the main render pass.
+type AAMode int
+
+const (
+	AANone AAMode = iota
+	AAMSAA
+	AAFXAA
+	AATAA
+)
+
+// Next cycles None -> MSAA -> FXAA -> TAA -> None.
+func (m AAMode) Next() AAMode {
+	return (m + 1) % 4
+}
+
+func (m AAMode) String() string {
+	switch m {
+	case AAMSAA:
+		return "MSAA"
+	case AAFXAA:
+		return "FXAA"
+	case AATAA:
+		return "TAA"
+	default:
+		return "None"
+	}
+}
+
+// taaBlendWeight is how much of the history buffer survives into the
+// resolved frame each tick; higher is smoother but ghosts more on fast
+// motion. taaClampRange bounds how far the history color can sit from
+// the current frame's color before it's clamped, which is the "simple
+// clamp" this pipeline uses to tame disocclusion ghosting in lieu of a
+// full velocity-buffer reprojection (there's no depth/velocity texture
+// plumbed through yet to do per-pixel reprojection properly).
+var (
+	taaBlendWeight = float32(0.9)
+	taaClampRange  = float32(0.25)
+)
+
+// taaHaltonSequence is the base-2/base-3 Halton sequence used to jitter
+// the projection matrix sub-pixel each frame; 8 samples is enough to
+// cycle through a stable pattern without needing extra state.
+var taaHaltonSequence = [8][2]float32{
+	{0.500, 0.333}, {0.250, 0.667}, {0.750, 0.111}, {0.125, 0.444},
+	{0.625, 0.778}, {0.375, 0.222}, {0.875, 0.556}, {0.0625, 0.889},
+}
+
+const msaaSamples = 4
+
+// PostPipeline owns the offscreen framebuffers used to implement MSAA
+// (via a multisampled renderbuffer resolved with a blit) and FXAA (a
+// full-screen post-process pass over a resolved color buffer), so the AA
+// method can be switched at runtime without recreating the GL context.
+type PostPipeline struct {
+	width, height int
+
+	msaaFBO, msaaColorRB, msaaDepthRB uint32
+
+	sceneFBO, sceneColorTex, sceneDepthRB uint32
+
+	fxaaProgram           uint32
+	fxaaTexUniform        int32
+	fxaaResolutionUniform int32
+	quadVAO, quadVBO      uint32
+
+	presentFBO, presentColorTex uint32
+	invertProgram               uint32
+	invertTexUniform            int32
+
+	toneFBO, toneColorTex uint32
+	toneProgram           uint32
+	toneTexUniform        int32
+	toneGammaUniform      int32
+	toneExposureUniform   int32
+
+	historyFBO        [2]uint32
+	historyColorTex   [2]uint32
+	historyIndex      int
+	haveHistory       bool
+	taaFrameIndex     int
+	taaProgram        uint32
+	taaCurrentUniform int32
+	taaHistoryUniform int32
+	taaBlendUniform   int32
+	taaClampUniform   int32
+}
+
+// NewPostPipeline builds the post-processing pipeline for a framebuffer of
+// the given size.
+func NewPostPipeline(width, height int) *PostPipeline {
+	p := &PostPipeline{}
+	p.buildQuad()
+	p.buildFXAAProgram()
+	p.buildInvertProgram()
+	p.buildTAAProgram()
+	p.buildToneMapProgram()
+	p.Resize(width, height)
+	return p
+}
+
+func (p *PostPipeline) buildQuad() {
+	// Two triangles covering clip space, with UVs for sampling the
+	// resolved scene texture.
+	vertices := []float32{
+		-1, -1, 0, 0,
+		1, -1, 1, 0,
+		1, 1, 1, 1,
+		-1, -1, 0, 0,
+		1, 1, 1, 1,
+		-1, 1, 0, 1,
+	}
+	gl.GenVertexArrays(1, &p.quadVAO)
+	trackCreate("vao")
+	gl.GenBuffers(1, &p.quadVBO)
+	trackCreate("buffer")
+	gl.BindVertexArray(p.quadVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, p.quadVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, 4*4, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, 4*4, 2*4)
+	gl.EnableVertexAttribArray(1)
+	gl.BindVertexArray(0)
+}
+
+func (p *PostPipeline) buildFXAAProgram() {
+	program, err := newProgram(fxaaVertexShader, fxaaFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	p.fxaaProgram = program
+	p.fxaaTexUniform = gl.GetUniformLocation(program, gl.Str("screenTex\x00"))
+	p.fxaaResolutionUniform = gl.GetUniformLocation(program, gl.Str("resolution\x00"))
+}
+
+func (p *PostPipeline) buildInvertProgram() {
+	program, err := newProgram(fxaaVertexShader, invertFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	p.invertProgram = program
+	p.invertTexUniform = gl.GetUniformLocation(program, gl.Str("screenTex\x00"))
+}
+
+func (p *PostPipeline) buildToneMapProgram() {
+	program, err := newProgram(fxaaVertexShader, toneMapFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	p.toneProgram = program
+	p.toneTexUniform = gl.GetUniformLocation(program, gl.Str("screenTex\x00"))
+	p.toneGammaUniform = gl.GetUniformLocation(program, gl.Str("gamma\x00"))
+	p.toneExposureUniform = gl.GetUniformLocation(program, gl.Str("exposure\x00"))
+}
+
+func (p *PostPipeline) buildTAAProgram() {
+	program, err := newProgram(fxaaVertexShader, taaResolveFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	p.taaProgram = program
+	p.taaCurrentUniform = gl.GetUniformLocation(program, gl.Str("currentTex\x00"))
+	p.taaHistoryUniform = gl.GetUniformLocation(program, gl.Str("historyTex\x00"))
+	p.taaBlendUniform = gl.GetUniformLocation(program, gl.Str("blendWeight\x00"))
+	p.taaClampUniform = gl.GetUniformLocation(program, gl.Str("clampRange\x00"))
+}
+
+// TAAJitter returns the next sub-pixel projection-matrix jitter offset,
+// in NDC units, and advances the jitter sequence. Call once per frame
+// while aaMode is AATAA, before building the projection matrix.
+func (p *PostPipeline) TAAJitter() (dx, dy float32) {
+	sample := taaHaltonSequence[p.taaFrameIndex%len(taaHaltonSequence)]
+	p.taaFrameIndex++
+	if p.width == 0 || p.height == 0 {
+		return 0, 0
+	}
+	dx = (sample[0]*2 - 1) / float32(p.width)
+	dy = (sample[1]*2 - 1) / float32(p.height)
+	return dx, dy
+}
+
+// Resize (re)allocates the offscreen framebuffers for a new viewport size.
+func (p *PostPipeline) Resize(width, height int) {
+	if width == 0 || height == 0 {
+		return
+	}
+	p.width, p.height = width, height
+	p.deleteFramebuffers()
+
+	gl.GenFramebuffers(1, &p.sceneFBO)
+	gl.GenTextures(1, &p.sceneColorTex)
+	gl.BindTexture(gl.TEXTURE_2D, p.sceneColorTex)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(width), int32(height), 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+
+	gl.GenRenderbuffers(1, &p.sceneDepthRB)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, p.sceneDepthRB)
+	gl.RenderbufferStorage(gl.RENDERBUFFER, gl.DEPTH_COMPONENT24, int32(width), int32(height))
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.sceneFBO)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, p.sceneColorTex, 0)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, p.sceneDepthRB)
+
+	gl.GenFramebuffers(1, &p.msaaFBO)
+	gl.GenRenderbuffers(1, &p.msaaColorRB)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, p.msaaColorRB)
+	gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, msaaSamples, gl.RGBA8, int32(width), int32(height))
+
+	gl.GenRenderbuffers(1, &p.msaaDepthRB)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, p.msaaDepthRB)
+	gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, msaaSamples, gl.DEPTH_COMPONENT24, int32(width), int32(height))
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.msaaFBO)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.RENDERBUFFER, p.msaaColorRB)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, p.msaaDepthRB)
+
+	gl.GenFramebuffers(1, &p.presentFBO)
+	gl.GenTextures(1, &p.presentColorTex)
+	gl.BindTexture(gl.TEXTURE_2D, p.presentColorTex)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(width), int32(height), 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.presentFBO)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, p.presentColorTex, 0)
+
+	gl.GenFramebuffers(1, &p.toneFBO)
+	gl.GenTextures(1, &p.toneColorTex)
+	gl.BindTexture(gl.TEXTURE_2D, p.toneColorTex)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(width), int32(height), 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.toneFBO)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, p.toneColorTex, 0)
+
+	for i := range p.historyFBO {
+		gl.GenFramebuffers(1, &p.historyFBO[i])
+		gl.GenTextures(1, &p.historyColorTex[i])
+		gl.BindTexture(gl.TEXTURE_2D, p.historyColorTex[i])
+		gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(width), int32(height), 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+		gl.BindFramebuffer(gl.FRAMEBUFFER, p.historyFBO[i])
+		gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, p.historyColorTex[i], 0)
+	}
+	// The history buffers were just reallocated at the new size, so any
+	// previous frame's history is gone; the next TAA resolve must treat
+	// itself as the first frame.
+	p.haveHistory = false
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+func (p *PostPipeline) deleteFramebuffers() {
+	if p.sceneFBO != 0 {
+		gl.DeleteFramebuffers(1, &p.sceneFBO)
+		gl.DeleteTextures(1, &p.sceneColorTex)
+		gl.DeleteRenderbuffers(1, &p.sceneDepthRB)
+	}
+	if p.msaaFBO != 0 {
+		gl.DeleteFramebuffers(1, &p.msaaFBO)
+		gl.DeleteRenderbuffers(1, &p.msaaColorRB)
+		gl.DeleteRenderbuffers(1, &p.msaaDepthRB)
+	}
+	if p.presentFBO != 0 {
+		gl.DeleteFramebuffers(1, &p.presentFBO)
+		gl.DeleteTextures(1, &p.presentColorTex)
+	}
+	if p.toneFBO != 0 {
+		gl.DeleteFramebuffers(1, &p.toneFBO)
+		gl.DeleteTextures(1, &p.toneColorTex)
+	}
+	for i := range p.historyFBO {
+		if p.historyFBO[i] != 0 {
+			gl.DeleteFramebuffers(1, &p.historyFBO[i])
+			gl.DeleteTextures(1, &p.historyColorTex[i])
+		}
+	}
+}
+
+// Begin binds the framebuffer appropriate for mode (the default
+// framebuffer for AANone).
+func (p *PostPipeline) Begin(mode AAMode) {
+	switch mode {
+	case AAMSAA:
+		gl.BindFramebuffer(gl.FRAMEBUFFER, p.msaaFBO)
+	case AAFXAA, AATAA:
+		gl.BindFramebuffer(gl.FRAMEBUFFER, p.sceneFBO)
+	default:
+		gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	}
+}
+
+// End resolves the frame rendered since Begin into the default
+// framebuffer: a blit for MSAA, an FXAA pass over the resolved color
+// buffer for FXAA, and nothing for AANone (already drawn to the screen).
+func (p *PostPipeline) End(mode AAMode) {
+	switch mode {
+	case AAMSAA:
+		gl.BindFramebuffer(gl.READ_FRAMEBUFFER, p.msaaFBO)
+		gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, 0)
+		gl.BlitFramebuffer(0, 0, int32(p.width), int32(p.height), 0, 0, int32(p.width), int32(p.height), gl.COLOR_BUFFER_BIT, gl.LINEAR)
+	case AAFXAA:
+		gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+		gl.Disable(gl.DEPTH_TEST)
+		gl.UseProgram(p.fxaaProgram)
+		gl.ActiveTexture(gl.TEXTURE0)
+		gl.BindTexture(gl.TEXTURE_2D, p.sceneColorTex)
+		gl.Uniform1i(p.fxaaTexUniform, 0)
+		gl.Uniform2f(p.fxaaResolutionUniform, float32(p.width), float32(p.height))
+		gl.BindVertexArray(p.quadVAO)
+		gl.DrawArrays(gl.TRIANGLES, 0, 6)
+		gl.BindVertexArray(0)
+		gl.Enable(gl.DEPTH_TEST)
+	case AATAA:
+		p.resolveTAA()
+	}
+}
+
+// resolveTAA blends the just-rendered (jittered) frame in sceneColorTex
+// with the previous frame's history, writes the result into the other
+// history buffer, and blits that into the default framebuffer. On the
+// very first frame there's no history yet, so it just seeds one instead
+// of blending.
+func (p *PostPipeline) resolveTAA() {
+	readIndex, writeIndex := p.historyIndex, 1-p.historyIndex
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.historyFBO[writeIndex])
+	gl.Disable(gl.DEPTH_TEST)
+	gl.UseProgram(p.taaProgram)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, p.sceneColorTex)
+	gl.Uniform1i(p.taaCurrentUniform, 0)
+	gl.ActiveTexture(gl.TEXTURE1)
+	gl.BindTexture(gl.TEXTURE_2D, p.historyColorTex[readIndex])
+	gl.Uniform1i(p.taaHistoryUniform, 1)
+	if p.haveHistory {
+		gl.Uniform1f(p.taaBlendUniform, taaBlendWeight)
+	} else {
+		gl.Uniform1f(p.taaBlendUniform, 0)
+	}
+	gl.Uniform1f(p.taaClampUniform, taaClampRange)
+	gl.BindVertexArray(p.quadVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	gl.Enable(gl.DEPTH_TEST)
+
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, p.historyFBO[writeIndex])
+	gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, 0)
+	gl.BlitFramebuffer(0, 0, int32(p.width), int32(p.height), 0, 0, int32(p.width), int32(p.height), gl.COLOR_BUFFER_BIT, gl.NEAREST)
+
+	p.historyIndex = writeIndex
+	p.haveHistory = true
+}
+
+// ApplyInvert re-draws whatever is currently in the default framebuffer
+// with an inverted, high-contrast color transform, for presenting the
+// dark-themed scene on a bright projector. It's a final override applied
+// after End, regardless of AA mode: it blits the already-resolved frame
+// into presentColorTex and replaces the default framebuffer's contents
+// with the inverted result.
+func (p *PostPipeline) ApplyInvert() {
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+	gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, p.presentFBO)
+	gl.BlitFramebuffer(0, 0, int32(p.width), int32(p.height), 0, 0, int32(p.width), int32(p.height), gl.COLOR_BUFFER_BIT, gl.NEAREST)
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.Disable(gl.DEPTH_TEST)
+	gl.UseProgram(p.invertProgram)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, p.presentColorTex)
+	gl.Uniform1i(p.invertTexUniform, 0)
+	gl.BindVertexArray(p.quadVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+// ApplyToneMap re-draws whatever is currently in the default framebuffer
+// through a gamma/exposure pass (see toneGamma/toneExposure in main.go).
+// Like ApplyInvert, it's a final override applied after End, regardless
+// of AA mode: it blits the already-resolved frame into toneColorTex and
+// replaces the default framebuffer's contents with the tone-mapped
+// result. This is also the seam future full-screen effects (bloom, a
+// proper FXAA replacement, ...) would hang off of.
+func (p *PostPipeline) ApplyToneMap(gamma, exposure float32) {
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+	gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, p.toneFBO)
+	gl.BlitFramebuffer(0, 0, int32(p.width), int32(p.height), 0, 0, int32(p.width), int32(p.height), gl.COLOR_BUFFER_BIT, gl.NEAREST)
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.Disable(gl.DEPTH_TEST)
+	gl.UseProgram(p.toneProgram)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, p.toneColorTex)
+	gl.Uniform1i(p.toneTexUniform, 0)
+	gl.Uniform1f(p.toneGammaUniform, gamma)
+	gl.Uniform1f(p.toneExposureUniform, exposure)
+	gl.BindVertexArray(p.quadVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+const fxaaVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 aPos;
+layout (location = 1) in vec2 aUV;
+out vec2 vUV;
+
+void main() {
+	gl_Position = vec4(aPos, 0.0, 1.0);
+	vUV = aUV;
+}
+` + "\x00"
+
+// fxaaFragmentShader is a standard console-era FXAA approximation: it
+// estimates edge direction from the luma of the four diagonal neighbors
+// and blurs along that direction.
+const fxaaFragmentShader = `
+#version 460 core
+in vec2 vUV;
+out vec4 FragColor;
+
+uniform sampler2D screenTex;
+uniform vec2 resolution;
+
+void main() {
+	vec2 texel = 1.0 / resolution;
+	vec3 rgbNW = texture(screenTex, vUV + vec2(-1.0, -1.0) * texel).rgb;
+	vec3 rgbNE = texture(screenTex, vUV + vec2( 1.0, -1.0) * texel).rgb;
+	vec3 rgbSW = texture(screenTex, vUV + vec2(-1.0,  1.0) * texel).rgb;
+	vec3 rgbSE = texture(screenTex, vUV + vec2( 1.0,  1.0) * texel).rgb;
+	vec3 rgbM  = texture(screenTex, vUV).rgb;
+
+	vec3 lumaWeight = vec3(0.299, 0.587, 0.114);
+	float lumaNW = dot(rgbNW, lumaWeight);
+	float lumaNE = dot(rgbNE, lumaWeight);
+	float lumaSW = dot(rgbSW, lumaWeight);
+	float lumaSE = dot(rgbSE, lumaWeight);
+
+	vec2 dir;
+	dir.x = -((lumaNW + lumaNE) - (lumaSW + lumaSE));
+	dir.y = ((lumaNW + lumaSW) - (lumaNE + lumaSE));
+
+	float dirReduce = max((lumaNW + lumaNE + lumaSW + lumaSE) * 0.03125, 1.0 / 128.0);
+	float rcpDirMin = 1.0 / (min(abs(dir.x), abs(dir.y)) + dirReduce);
+	dir = clamp(dir * rcpDirMin, -8.0, 8.0) * texel;
+
+	vec3 rgbA = 0.5 * (
+		texture(screenTex, vUV + dir * (1.0 / 3.0 - 0.5)).rgb +
+		texture(screenTex, vUV + dir * (2.0 / 3.0 - 0.5)).rgb);
+	vec3 rgbB = rgbA * 0.5 + 0.25 * (
+		texture(screenTex, vUV + dir * -0.5).rgb +
+		texture(screenTex, vUV + dir * 0.5).rgb);
+
+	FragColor = vec4(rgbB, 1.0);
+}
+` + "\x00"
+
+// invertFragmentShader implements presentation mode: a simple color
+// inversion, which turns the app's dark background bright and vice
+// versa so it stays readable projected in a lit room.
+const invertFragmentShader = `
+#version 460 core
+in vec2 vUV;
+out vec4 FragColor;
+
+uniform sampler2D screenTex;
+
+void main() {
+	vec3 color = texture(screenTex, vUV).rgb;
+	FragColor = vec4(vec3(1.0) - color, 1.0);
+}
+` + "\x00"
+
+// toneMapFragmentShader applies exposure (a linear pre-scale of scene
+// color) followed by gamma correction (an inverse-gamma power curve),
+// the standard minimal tone-mapping pass before display.
+const toneMapFragmentShader = `
+#version 460 core
+in vec2 vUV;
+out vec4 FragColor;
+
+uniform sampler2D screenTex;
+uniform float gamma;
+uniform float exposure;
+
+void main() {
+	vec3 color = texture(screenTex, vUV).rgb * exposure;
+	color = pow(color, vec3(1.0 / gamma));
+	FragColor = vec4(color, 1.0);
+}
+` + "\x00"
+
+// taaResolveFragmentShader blends the current jittered frame with the
+// history buffer. There's no per-pixel reprojection here (that needs a
+// velocity buffer this pipeline doesn't have yet); disocclusion is
+// instead handled with a plain clamp of the history color towards the
+// current one, which hides most ghosting on typical camera motion.
+const taaResolveFragmentShader = `
+#version 460 core
+in vec2 vUV;
+out vec4 FragColor;
+
+uniform sampler2D currentTex;
+uniform sampler2D historyTex;
+uniform float blendWeight;
+uniform float clampRange;
+
+void main() {
+	vec3 cur = texture(currentTex, vUV).rgb;
+	vec3 hist = texture(historyTex, vUV).rgb;
+	hist = clamp(hist, cur - clampRange, cur + clampRange);
+	FragColor = vec4(mix(cur, hist, blendWeight), 1.0);
+}
+` + "\x00"
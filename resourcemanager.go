@@ -0,0 +1,67 @@
+package main
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// ResourceKind identifies which gl.Delete* call a registered handle
+// needs; see ResourceManager.
+type ResourceKind int
+
+const (
+	ResourceBuffer ResourceKind = iota
+	ResourceVAO
+	ResourceTexture
+	ResourceProgram
+)
+
+// managedResource pairs a GL handle with the kind of object it is, so
+// ResourceManager.DeleteAll knows which gl.Delete* call to make for it.
+type managedResource struct {
+	kind   ResourceKind
+	handle uint32
+}
+
+// ResourceManager accumulates GL objects as they're created and frees
+// all of them in one DeleteAll call at shutdown, so adding a new
+// long-lived object doesn't also require remembering to add a matching
+// Delete call to App.Cleanup. newProgram/newGeometryProgram (shader.go)
+// register every shader program they compile, and Mesh registers its
+// own vao/vbo/instanceVBO; most of this package's other GL types still
+// rely on their own Delete method plus the lighter-weight
+// trackCreate/trackDelete leak counters (see reportLeaks) instead -
+// migrating everything onto ResourceManager is future work, not
+// attempted wholesale here. A handle that's deleted early by its own
+// Delete/SetProgram and then re-registered under a reused id (e.g.
+// reloadModelShader swapping the model's program) can end up with two
+// entries for the same id; harmless, since DeleteAll only ever runs
+// once, at final shutdown, when every handle should go regardless.
+type ResourceManager struct {
+	items []managedResource
+}
+
+// resources is the package-wide ResourceManager; see App.Cleanup, which
+// calls DeleteAll on it before tearing down the GL context.
+var resources = &ResourceManager{}
+
+// Register records handle as a kind-tagged resource for a later
+// DeleteAll.
+func (r *ResourceManager) Register(kind ResourceKind, handle uint32) {
+	r.items = append(r.items, managedResource{kind: kind, handle: handle})
+}
+
+// DeleteAll frees every resource registered so far, in registration
+// order, and clears the registry.
+func (r *ResourceManager) DeleteAll() {
+	for _, res := range r.items {
+		switch res.kind {
+		case ResourceBuffer:
+			gl.DeleteBuffers(1, &res.handle)
+		case ResourceVAO:
+			gl.DeleteVertexArrays(1, &res.handle)
+		case ResourceTexture:
+			gl.DeleteTextures(1, &res.handle)
+		case ResourceProgram:
+			gl.DeleteProgram(res.handle)
+		}
+	}
+	r.items = nil
+}
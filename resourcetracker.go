@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// glLeakTrackingEnabled gates the GL resource leak tracker below. It's
+// off by default since the counters add a bit of bookkeeping to every
+// Gen*/Delete* call; set GL_LEAK_DEBUG=1 to turn it on while chasing a
+// leak in the dynamic grid/mesh-swap code paths.
+var glLeakTrackingEnabled = os.Getenv("GL_LEAK_DEBUG") == "1"
+
+// resourceCounts tracks live (created minus deleted) GL objects per
+// resource kind ("buffer", "vao", "program"), so a leak shows up as a
+// nonzero count instead of silently growing driver memory.
+var resourceCounts = map[string]int{}
+
+func trackCreate(kind string) {
+	if glLeakTrackingEnabled {
+		resourceCounts[kind]++
+	}
+}
+
+func trackDelete(kind string) {
+	if glLeakTrackingEnabled {
+		resourceCounts[kind]--
+	}
+}
+
+// reportLeaks returns a human-readable summary of any GL resource kind
+// with a nonzero live count, or "" if nothing leaked. Call at shutdown.
+func reportLeaks() string {
+	if !glLeakTrackingEnabled {
+		return ""
+	}
+	var leaks []string
+	for kind, count := range resourceCounts {
+		if count != 0 {
+			leaks = append(leaks, fmt.Sprintf("%s: %d", kind, count))
+		}
+	}
+	if len(leaks) == 0 {
+		return ""
+	}
+	return "GL resource leak report - " + strings.Join(leaks, ", ")
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// eulerRotation builds a rotation matrix from degrees around X, Y, then
+// Z - the order WireCube.Rotation and loadScene's "cube"/"model" objects
+// share.
+func eulerRotation(degrees mgl32.Vec3) mgl32.Mat4 {
+	return mgl32.HomogRotate3DZ(mgl32.DegToRad(degrees.Z())).
+		Mul4(mgl32.HomogRotate3DY(mgl32.DegToRad(degrees.Y()))).
+		Mul4(mgl32.HomogRotate3DX(mgl32.DegToRad(degrees.X())))
+}
+
+// ObjectKind identifies which primitive an Object wraps; see loadScene.
+type ObjectKind int
+
+const (
+	ObjectKindCube ObjectKind = iota
+	ObjectKindGrid
+	ObjectKindLine
+	ObjectKindModel
+	ObjectKindPoints
+)
+
+// Object is one entry from a scene file, built by loadScene: a
+// primitive (cube, grid, line, or a loaded OBJ model) plus whatever it
+// takes to Draw it. Only one of the unexported fields is set, matching
+// Kind - main.go never touches them directly.
+type Object struct {
+	Kind ObjectKind
+
+	cube   *WireCube
+	grid   *Grid
+	line   *sceneLine
+	mesh   *Mesh
+	points *PointCloud
+
+	// modelTransform is the model-space-to-world matrix Draw passes to
+	// mesh.Draw; only meaningful when Kind is ObjectKindModel, since
+	// Mesh (unlike WireCube) has no position/scale fields of its own.
+	modelTransform mgl32.Mat4
+}
+
+// Draw renders the object's underlying primitive. view/projection for
+// cube/line/model come from the shared Matrices UBO (see
+// updateMatricesUBO), same as every other scene object; grid objects
+// built from a scene file are static - they don't call UpdateFollow the
+// way main.go's built-in infinite ground grid does.
+func (o *Object) Draw() {
+	switch o.Kind {
+	case ObjectKindCube:
+		o.cube.Draw()
+	case ObjectKindGrid:
+		o.grid.Draw()
+	case ObjectKindLine:
+		o.line.Draw()
+	case ObjectKindModel:
+		o.mesh.Draw(o.modelTransform)
+	case ObjectKindPoints:
+		o.points.Draw()
+	}
+}
+
+// Delete frees the object's underlying GL resources.
+func (o *Object) Delete() {
+	switch o.Kind {
+	case ObjectKindCube:
+		o.cube.Delete()
+	case ObjectKindGrid:
+		o.grid.Delete()
+	case ObjectKindLine:
+		o.line.Delete()
+	case ObjectKindModel:
+		o.mesh.Delete()
+	case ObjectKindPoints:
+		o.points.Delete()
+	}
+}
+
+// sceneFileJSON is the on-disk shape of a scene file; see loadScene.
+type sceneFileJSON struct {
+	Objects []sceneObjectJSON `json:"objects"`
+}
+
+// sceneObjectJSON mirrors one Object. Which fields apply depends on
+// Type: Position/Rotation/Scale/Color apply to "cube" and "model",
+// Spacing/Lines/Color apply to "grid", From/To/Color apply to "line",
+// and Points/Color/Size apply to "points". Fields that don't apply to a
+// given Type are simply ignored, the same "extra JSON is harmless"
+// leniency json.Unmarshal already gives every other loader in this
+// package (LoadKeyBindings, loadCameraState).
+type sceneObjectJSON struct {
+	Type     string     `json:"type"`
+	Position [3]float32 `json:"position"`
+	Rotation [3]float32 `json:"rotation"`
+	Scale    float32    `json:"scale"`
+	Color    [3]float32 `json:"color"`
+
+	Spacing float32 `json:"spacing"`
+	Lines   int     `json:"lines"`
+
+	From [3]float32 `json:"from"`
+	To   [3]float32 `json:"to"`
+
+	Path string `json:"path"`
+
+	// Points and Size apply to "points" - a scatter-plot object rendered
+	// via addPoints. Size is the point diameter in screen pixels.
+	Points [][3]float32 `json:"points"`
+	Size   float32      `json:"size"`
+}
+
+// loadScene reads a JSON scene file describing a list of primitives -
+// "cube", "grid", "line", "points" (a scatter-plot PointCloud, see
+// addPoints), or "model" (an OBJ file loaded via loadOBJ, positioned/
+// scaled/rotated by the object's own transform rather than the -model
+// flag's) - and returns one Object per entry, in file order.
+// Only JSON is supported; this repo has no YAML dependency to parse the
+// format with, and adding one for a single loader didn't seem worth it.
+// An object with an unrecognized Type, or a "model" object whose Path
+// fails to load, is skipped with a logged warning rather than failing
+// the whole file - one bad entry shouldn't cost the rest of the scene.
+func loadScene(path string) ([]*Object, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scene: %w", err)
+	}
+	var raw sceneFileJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse scene: %w", err)
+	}
+
+	var objects []*Object
+	for i, o := range raw.Objects {
+		scale := o.Scale
+		if scale == 0 {
+			scale = 1
+		}
+		color := mgl32.Vec3{o.Color[0], o.Color[1], o.Color[2]}
+		if color == (mgl32.Vec3{}) {
+			color = mgl32.Vec3{1, 1, 1}
+		}
+		position := mgl32.Vec3{o.Position[0], o.Position[1], o.Position[2]}
+		rotation := mgl32.Vec3{o.Rotation[0], o.Rotation[1], o.Rotation[2]}
+
+		switch o.Type {
+		case "cube":
+			cube := NewWireCube(position, scale, color)
+			cube.Rotation = rotation
+			cube.Solid = true
+			cube.SolidColor = color
+			objects = append(objects, &Object{Kind: ObjectKindCube, cube: cube})
+
+		case "grid":
+			spacing := o.Spacing
+			if spacing == 0 {
+				spacing = 1
+			}
+			lines := o.Lines
+			if lines == 0 {
+				lines = 20
+			}
+			objects = append(objects, &Object{Kind: ObjectKindGrid, grid: NewGrid(lines, spacing, color)})
+
+		case "line":
+			from := mgl32.Vec3{o.From[0], o.From[1], o.From[2]}
+			to := mgl32.Vec3{o.To[0], o.To[1], o.To[2]}
+			objects = append(objects, &Object{Kind: ObjectKindLine, line: newSceneLine(from, to, color)})
+
+		case "points":
+			if len(o.Points) == 0 {
+				log.Printf("scene: object %d is type %q but has no points, skipping", i, o.Type)
+				continue
+			}
+			size := o.Size
+			if size == 0 {
+				size = 4
+			}
+			points := make([]mgl32.Vec3, len(o.Points))
+			for j, p := range o.Points {
+				points[j] = mgl32.Vec3{p[0], p[1], p[2]}
+			}
+			objects = append(objects, &Object{Kind: ObjectKindPoints, points: NewPointCloud(points, color, size)})
+
+		case "model":
+			if o.Path == "" {
+				log.Printf("scene: object %d is type %q but has no path, skipping", i, o.Type)
+				continue
+			}
+			mesh, err := loadOBJ(o.Path, float32(*weldToleranceFlag))
+			if err != nil {
+				log.Printf("scene: object %d: load model %q: %v", i, o.Path, err)
+				continue
+			}
+			transform := mgl32.Translate3D(position.X(), position.Y(), position.Z()).
+				Mul4(eulerRotation(rotation)).
+				Mul4(mgl32.Scale3D(scale, scale, scale))
+			objects = append(objects, &Object{Kind: ObjectKindModel, mesh: mesh, modelTransform: transform})
+
+		default:
+			log.Printf("scene: object %d has unrecognized type %q, skipping", i, o.Type)
+		}
+	}
+	return objects, nil
+}
+
+// sceneLine is a single straight segment between two points, used by
+// scene files' "line" primitive. It reuses WireCube's outline shader
+// (position-only, uniform model+color) rather than building a third
+// near-identical line shader just for this.
+type sceneLine struct {
+	program      uint32
+	vao, vbo     uint32
+	modelUniform int32
+	colorUniform int32
+	color        mgl32.Vec3
+}
+
+func newSceneLine(from, to mgl32.Vec3, color mgl32.Vec3) *sceneLine {
+	program, err := newProgram(outlineVertexShader, outlineFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	vertices := []float32{from.X(), from.Y(), from.Z(), to.X(), to.Y(), to.Z()}
+	vao, vbo := newInterleavedBuffer(vertices, 3)
+
+	return &sceneLine{
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("color\x00")),
+		color:        color,
+	}
+}
+
+// Draw renders the segment at a base line width of 1 (still scaled by
+// -linewidth via setLineWidth), since a scene-file line is typically meant to read as a
+// thin reference edge rather than a thick axis.
+func (l *sceneLine) Draw() {
+	identity := mgl32.Ident4()
+	gl.UseProgram(l.program)
+	gl.UniformMatrix4fv(l.modelUniform, 1, false, &identity[0])
+	gl.Uniform4f(l.colorUniform, l.color.X(), l.color.Y(), l.color.Z(), 1)
+
+	blending := beginLineBlend()
+	gl.BindVertexArray(l.vao)
+	setLineWidth(1)
+	gl.DrawArrays(gl.LINES, 0, 2)
+	gl.BindVertexArray(0)
+	endLineBlend(blending)
+}
+
+// Delete frees the line's GL resources.
+func (l *sceneLine) Delete() {
+	gl.DeleteVertexArrays(1, &l.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &l.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(l.program)
+	trackDelete("program")
+}
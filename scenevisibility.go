@@ -0,0 +1,30 @@
+package main
+
+// SceneObjects tracks which of the scene's toggleable elements are
+// currently drawn. Most of the scene's other toggles (infiniteGrid,
+// fogEnabled, showNormals, ...) are scattered package-level booleans
+// read directly by their own Draw method; visibility is different in
+// that it's one cohesive concept spanning several unrelated types
+// (Axes, Grid, WireCube), so it gets its own struct instead of growing
+// that list further - drawSceneCore reads it before each conditional
+// Draw call, and keyCallback's digit keys are the only thing that
+// mutates it.
+type SceneObjects struct {
+	AxisX bool
+	AxisY bool
+	AxisZ bool
+	Grid  bool
+	Cube  bool
+}
+
+// NewSceneObjects returns every element visible, matching the scene's
+// appearance before these toggles existed.
+func NewSceneObjects() *SceneObjects {
+	return &SceneObjects{
+		AxisX: true,
+		AxisY: true,
+		AxisZ: true,
+		Grid:  true,
+		Cube:  true,
+	}
+}
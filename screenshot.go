@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"time"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// captureScreenshot reads the default framebuffer's current contents and
+// writes it to path as a PNG, flipping rows vertically since OpenGL's
+// framebuffer origin is bottom-left but image formats expect top-left.
+// It uses the live fbWidth/fbHeight rather than the startup windowWidth/
+// windowHeight, so a resized window is captured at its actual
+// size.
+func captureScreenshot(path string) error {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.ReadBuffer(gl.BACK)
+
+	rowSize := fbWidth * 4
+	pixels := make([]uint8, rowSize*fbHeight)
+	gl.ReadPixels(0, 0, int32(fbWidth), int32(fbHeight), gl.RGBA, gl.UNSIGNED_BYTE, safeGLPtr(pixels))
+
+	img := image.NewRGBA(image.Rect(0, 0, fbWidth, fbHeight))
+	for y := 0; y < fbHeight; y++ {
+		srcStart := y * rowSize
+		dstStart := (fbHeight - 1 - y) * rowSize
+		copy(img.Pix[dstStart:dstStart+rowSize], pixels[srcStart:srcStart+rowSize])
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create screenshot file: %w", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("encode screenshot: %w", err)
+	}
+	return nil
+}
+
+// screenshotPath returns a timestamped filename for the next screenshot.
+func screenshotPath() string {
+	return fmt.Sprintf("screenshot-%s.png", time.Now().Format("20060102-150405"))
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// lineVertexShader and lineFragmentShader render flat-colored vertex data
+// (grid, axes, debug overlays) with a per-object model uniform and
+// view/projection from the shared Matrices UBO.
+const lineVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec4 aColor;
+
+uniform mat4 model;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+out vec4 vColor;
+
+void main() {
+	gl_Position = projection * view * model * vec4(aPos, 1.0);
+	vColor = aColor;
+}
+` + "\x00"
+
+const lineFragmentShader = `
+#version 460 core
+in vec4 vColor;
+out vec4 FragColor;
+
+void main() {
+	FragColor = vColor;
+}
+` + "\x00"
+
+// gridVertexShader/gridFragmentShader are lineVertexShader/
+// lineFragmentShader plus exponential fog, used only by Grid so that
+// axes and other flat-colored overlays stay exempt from it (see
+// fogEnabled/fogDensity/fogColor in main.go). fadeAlpha further scales
+// every line's alpha at draw time, on top of the alpha baked into
+// vColor; see Grid.FadeAlpha and adaptiveGrid's decade crossfade.
+const gridVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec4 aColor;
+
+uniform mat4 model;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+out vec4 vColor;
+out float vViewDist;
+
+void main() {
+	vec4 viewPos = view * model * vec4(aPos, 1.0);
+	gl_Position = projection * viewPos;
+	vColor = aColor;
+	vViewDist = length(viewPos.xyz);
+}
+` + "\x00"
+
+const gridFragmentShader = `
+#version 460 core
+in vec4 vColor;
+in float vViewDist;
+out vec4 FragColor;
+
+uniform bool fogEnabled;
+uniform float fogDensity;
+uniform vec3 fogColor;
+uniform float fadeAlpha;
+
+void main() {
+	vec3 rgb = vColor.rgb;
+	if (fogEnabled) {
+		float fogFactor = clamp(exp(-fogDensity * vViewDist), 0.0, 1.0);
+		rgb = mix(fogColor, rgb, fogFactor);
+	}
+	FragColor = vec4(rgb, vColor.a * fadeAlpha);
+}
+` + "\x00"
+
+// newProgram compiles and links a vertex/fragment shader pair and returns
+// the resulting program handle.
+func newProgram(vertexSrc, fragmentSrc string) (uint32, error) {
+	vertex, err := compileShader(vertexSrc, gl.VERTEX_SHADER)
+	if err != nil {
+		return 0, err
+	}
+	fragment, err := compileShader(fragmentSrc, gl.FRAGMENT_SHADER)
+	if err != nil {
+		return 0, err
+	}
+
+	program := gl.CreateProgram()
+	trackCreate("program")
+	gl.AttachShader(program, vertex)
+	gl.AttachShader(program, fragment)
+	gl.LinkProgram(program)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(log))
+		return 0, fmt.Errorf("link program: %v", log)
+	}
+
+	gl.DeleteShader(vertex)
+	gl.DeleteShader(fragment)
+	resources.Register(ResourceProgram, program)
+	bindSharedUBOs(program)
+	return program, nil
+}
+
+// newGeometryProgram compiles and links a vertex/geometry/fragment shader
+// triple.
+func newGeometryProgram(vertexSrc, geometrySrc, fragmentSrc string) (uint32, error) {
+	vertex, err := compileShader(vertexSrc, gl.VERTEX_SHADER)
+	if err != nil {
+		return 0, err
+	}
+	geometry, err := compileShader(geometrySrc, gl.GEOMETRY_SHADER)
+	if err != nil {
+		return 0, err
+	}
+	fragment, err := compileShader(fragmentSrc, gl.FRAGMENT_SHADER)
+	if err != nil {
+		return 0, err
+	}
+
+	program := gl.CreateProgram()
+	trackCreate("program")
+	gl.AttachShader(program, vertex)
+	gl.AttachShader(program, geometry)
+	gl.AttachShader(program, fragment)
+	gl.LinkProgram(program)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(log))
+		return 0, fmt.Errorf("link program: %v", log)
+	}
+
+	gl.DeleteShader(vertex)
+	gl.DeleteShader(geometry)
+	gl.DeleteShader(fragment)
+	resources.Register(ResourceProgram, program)
+	bindSharedUBOs(program)
+	return program, nil
+}
+
+// bindSharedUBOs explicitly binds program's Matrices uniform block to
+// matricesUBOBinding via glUniformBlockBinding, the pre-4.2 equivalent
+// of the shader's own `layout(..., binding = 0)` qualifier -
+// rewriteShaderVersion strips that qualifier below GLSL 420, since the
+// explicit-binding syntax itself needs it, so this is what makes the
+// shared UBO still reach the shader on an older context. A no-op (and
+// harmless) on 4.2+, where the qualifier already did the job, and for
+// any program with no Matrices block at all.
+func bindSharedUBOs(program uint32) {
+	if glslVersion() >= glslBindingQualifierMinVersion {
+		return
+	}
+	index := gl.GetUniformBlockIndex(program, gl.Str("Matrices\x00"))
+	if index == 0xFFFFFFFF {
+		return
+	}
+	gl.UniformBlockBinding(program, index, matricesUBOBinding)
+}
+
+// loadShaderFromFiles compiles a vertex/fragment shader pair read from
+// vertPath/fragPath through the same compile path as the embedded
+// shaders, so iterating on a shader no longer requires recompiling the
+// program. Both paths must be non-empty; callers fall back to an
+// embedded default themselves when the user hasn't pointed at files.
+func loadShaderFromFiles(vertPath, fragPath string) (uint32, error) {
+	vertSrc, err := os.ReadFile(vertPath)
+	if err != nil {
+		return 0, fmt.Errorf("read vertex shader: %w", err)
+	}
+	fragSrc, err := os.ReadFile(fragPath)
+	if err != nil {
+		return 0, fmt.Errorf("read fragment shader: %w", err)
+	}
+	return newProgram(string(vertSrc)+"\x00", string(fragSrc)+"\x00")
+}
+
+func compileShader(source string, shaderType uint32) (uint32, error) {
+	shader := gl.CreateShader(shaderType)
+
+	csource, free := gl.Strs(rewriteShaderVersion(source))
+	gl.ShaderSource(shader, 1, csource, nil)
+	free()
+	gl.CompileShader(shader)
+
+	var status int32
+	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &logLength)
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(log))
+		return 0, fmt.Errorf("compile shader: %v", log)
+	}
+
+	return shader, nil
+}
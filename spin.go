@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// spinAxisFlag enables an animated spin on the reference cube (see
+// WireCube.SpinAxis/SpinAngle in wirecube.go) around an arbitrary axis
+// given as "x,y,z"; unset (the default) leaves the cube static, same as
+// before this existed.
+var spinAxisFlag = flag.String("spin-axis", "", `axis to spin the reference cube around, as "x,y,z"; set to enable spin animation`)
+
+// spinRateFlag is the spin rate in degrees per second, only meaningful
+// when -spin-axis enables the animation.
+var spinRateFlag = flag.Float64("spin-rate", 30, "spin rate in degrees per second")
+
+// spinEnabled mirrors whether -spin-axis parsed successfully; spinPaused
+// additionally pauses/resumes the animation via glfw.KeyJ (see
+// keyCallback's glfw.KeyJ case) without losing the
+// configured axis/rate.
+var (
+	spinEnabled bool
+	spinPaused  bool
+	spinRate    float32
+)
+
+// initSpin parses -spin-axis/-spin-rate and, if a valid axis was given,
+// enables the animation on demoCube.
+func initSpin() {
+	if *spinAxisFlag == "" {
+		return
+	}
+	axis, err := parseSpinAxis(*spinAxisFlag)
+	if err != nil {
+		log.Printf("parse -spin-axis: %v; spin animation disabled", err)
+		return
+	}
+	demoCube.SpinAxis = axis
+	spinRate = float32(*spinRateFlag)
+	spinEnabled = true
+}
+
+// updateSpin advances demoCube's spin angle by spinRate*dt, called once
+// per frame from the render loop alongside the camera's other per-frame
+// updates. A no-op unless -spin-axis enabled the animation and it isn't
+// currently paused.
+func updateSpin(dt float64) {
+	if !spinEnabled || spinPaused {
+		return
+	}
+	demoCube.SpinAngle += spinRate * float32(dt)
+}
+
+// parseSpinAxis parses a comma-separated "x,y,z" triple, the same format
+// -spin-axis takes.
+func parseSpinAxis(s string) (mgl32.Vec3, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return mgl32.Vec3{}, fmt.Errorf("%q: want 3 comma-separated numbers", s)
+	}
+	var v mgl32.Vec3
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return mgl32.Vec3{}, fmt.Errorf("%q: %w", s, err)
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import "fmt"
+
+// fpsHistorySize bounds how many recent frames RenderStats keeps around
+// for the FPS overlay graph.
+const fpsHistorySize = 120
+
+// RenderStats accumulates per-frame timings for the lifetime of the
+// process, so a short summary can be printed on exit.
+type RenderStats struct {
+	frames       int64
+	totalSeconds float64
+	minFrameTime float64
+	maxFrameTime float64
+
+	// fpsHistory is a ring buffer of recent instantaneous FPS values,
+	// oldest first, used to draw the FPS overlay plot.
+	fpsHistory []float32
+}
+
+// NewRenderStats returns a zeroed RenderStats ready to record frames.
+func NewRenderStats() *RenderStats {
+	return &RenderStats{minFrameTime: -1}
+}
+
+// RecordFrame logs one frame's delta time, in seconds.
+func (s *RenderStats) RecordFrame(dt float64) {
+	s.frames++
+	s.totalSeconds += dt
+	if s.minFrameTime < 0 || dt < s.minFrameTime {
+		s.minFrameTime = dt
+	}
+	if dt > s.maxFrameTime {
+		s.maxFrameTime = dt
+	}
+
+	if dt > 0 {
+		s.fpsHistory = append(s.fpsHistory, float32(1/dt))
+		if len(s.fpsHistory) > fpsHistorySize {
+			s.fpsHistory = s.fpsHistory[len(s.fpsHistory)-fpsHistorySize:]
+		}
+	}
+}
+
+// Summary renders a human-readable report of frames rendered, elapsed
+// time, and average/min/max FPS.
+func (s *RenderStats) Summary() string {
+	if s.frames == 0 || s.totalSeconds == 0 {
+		return "render stats: no frames recorded"
+	}
+	avgFPS := float64(s.frames) / s.totalSeconds
+	minFPS := 1.0 / s.maxFrameTime
+	maxFPS := 1.0 / s.minFrameTime
+	return fmt.Sprintf(
+		"render stats: %d frames in %.1fs (avg %.1f fps, min %.1f fps, max %.1f fps)",
+		s.frames, s.totalSeconds, avgFPS, minFPS, maxFPS,
+	)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// stressCubes are the -stress benchmark's randomly-placed cubes, drawn
+// by drawSceneCore alongside every other scene object like pointClouds/
+// polylines.
+var stressCubes []*WireCube
+
+// stressSpawnRadius/stressCubeSize bound where spawnStressCubes scatters
+// cubes and how big each one is - small and dense enough that a
+// reasonable N keeps most of them in view and overlapping, which is the
+// point of a load test.
+const (
+	stressSpawnRadius = 20.0
+	stressCubeSize    = 0.5
+)
+
+// spawnStressCubes fills stressCubes with n solid cubes at uniformly
+// random positions within a stressSpawnRadius cube centered on the
+// origin, for -stress to exercise the draw loop under a known load.
+func spawnStressCubes(n int) {
+	stressCubes = make([]*WireCube, 0, n)
+	for i := 0; i < n; i++ {
+		position := mgl32.Vec3{
+			(rand.Float32()*2 - 1) * stressSpawnRadius,
+			(rand.Float32()*2 - 1) * stressSpawnRadius,
+			(rand.Float32()*2 - 1) * stressSpawnRadius,
+		}
+		color := mgl32.Vec3{rand.Float32(), rand.Float32(), rand.Float32()}
+		cube := NewWireCube(position, stressCubeSize, color)
+		cube.Solid = true
+		stressCubes = append(stressCubes, cube)
+	}
+	log.Printf("stress: spawned %d cubes", n)
+}
+
+// renderStress renders offscreen as fast as possible for stressDuration,
+// recording each frame's render time, then logs min/max/avg/p99 and
+// exits - a reproducible number for comparing draw-loop changes (e.g.
+// instancing, frustum culling) against each other. It reuses drawScene
+// rather than the interactive loop's timestep/input handling, the same
+// way renderHeadlessFrame/renderRecording do for their own offscreen
+// paths.
+func renderStress(window *glfw.Window) {
+	var frameTimes []float64
+	deadline := time.Now().Add(*stressDuration)
+
+	for time.Now().Before(deadline) {
+		start := time.Now()
+
+		postPipeline.Begin(aaMode)
+		gl.Viewport(0, 0, int32(fbWidth), int32(fbHeight))
+		gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+		view := camera.ViewMatrix()
+		projection := camera.ProjectionMatrix(aspectRatio(fbWidth, fbHeight), nearPlane, farPlane)
+		updateMatricesUBO(matricesUBO, projection, view)
+		drawScene(window, view, projection, fbWidth, fbHeight)
+
+		postPipeline.End(aaMode)
+		if toneMappingEnabled {
+			postPipeline.ApplyToneMap(toneGamma, toneExposure)
+		}
+		if invertColors {
+			postPipeline.ApplyInvert()
+		}
+
+		window.SwapBuffers()
+		glfw.PollEvents()
+
+		frameTimes = append(frameTimes, time.Since(start).Seconds())
+	}
+
+	log.Println(summarizeStress(frameTimes))
+}
+
+// summarizeStress formats -stress's min/max/avg/p99 frame times, in
+// milliseconds, from the recorded per-frame durations in seconds.
+func summarizeStress(frameTimes []float64) string {
+	if len(frameTimes) == 0 {
+		return "stress: no frames recorded"
+	}
+
+	sorted := append([]float64(nil), frameTimes...)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, t := range sorted {
+		total += t
+	}
+	avg := total / float64(len(sorted))
+	p99Index := int(float64(len(sorted))*0.99) - 1
+	if p99Index < 0 {
+		p99Index = 0
+	}
+
+	return fmt.Sprintf(
+		"stress: %d cubes, %d frames - min %.2fms, max %.2fms, avg %.2fms, p99 %.2fms",
+		len(stressCubes), len(sorted), sorted[0]*1000, sorted[len(sorted)-1]*1000, avg*1000, sorted[p99Index]*1000,
+	)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"image"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// textPixelsPerUnit controls how large rendered text appears in world
+// space: higher values shrink a label of the same pixel size.
+const textPixelsPerUnit = 64.0
+
+// TextLabel renders a short string, rasterized once to a texture, as a
+// textured quad positioned at a fixed world coordinate. By default it
+// lies flat facing +Z; set Billboard to instead orient it to face the
+// camera each frame (see Draw), and Color to tint it (default white).
+type TextLabel struct {
+	texture  uint32
+	vao, vbo uint32
+	program  uint32
+
+	modelUniform int32
+	colorUniform int32
+
+	position       mgl32.Vec3
+	worldW, worldH float32
+
+	Billboard bool
+	Color     mgl32.Vec3
+}
+
+// NewTextLabel rasterizes text with a built-in bitmap font and places it
+// at the given world position.
+func NewTextLabel(text string, position mgl32.Vec3) *TextLabel {
+	face := basicfont.Face7x13
+	bounds, _ := font.BoundString(face, text)
+	w := (bounds.Max.X - bounds.Min.X).Ceil()
+	h := face.Metrics().Height.Ceil()
+	if w <= 0 {
+		w = 1
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.White,
+		Face: face,
+		Dot:  fixed.P(0, face.Metrics().Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+
+	var texture uint32
+	gl.GenTextures(1, &texture)
+	gl.BindTexture(gl.TEXTURE_2D, texture)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(w), int32(h), 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+
+	worldW := float32(w) / textPixelsPerUnit
+	worldH := float32(h) / textPixelsPerUnit
+
+	vertices := []float32{
+		0, 0, 0, 1,
+		worldW, 0, 1, 1,
+		worldW, worldH, 1, 0,
+		0, 0, 0, 1,
+		worldW, worldH, 1, 0,
+		0, worldH, 0, 0,
+	}
+
+	program, err := newProgram(textVertexShader, textFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+	gl.UseProgram(program)
+	gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("tex\x00")), 0)
+
+	var vao, vbo uint32
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, 4*4, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, 4*4, 2*4)
+	gl.EnableVertexAttribArray(1)
+	gl.BindVertexArray(0)
+
+	return &TextLabel{
+		texture:      texture,
+		vao:          vao,
+		vbo:          vbo,
+		program:      program,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		colorUniform: gl.GetUniformLocation(program, gl.Str("tintColor\x00")),
+		position:     position,
+		worldW:       worldW,
+		worldH:       worldH,
+		Color:        mgl32.Vec3{1, 1, 1},
+	}
+}
+
+// Draw renders the label, facing +Z by default, or facing the camera if
+// Billboard is set, using view's right/up rows directly rather than
+// inverting it. projection comes from the shared Matrices UBO (see
+// updateMatricesUBO); view is still taken directly since the billboard
+// math needs it on the CPU side too.
+func (t *TextLabel) Draw(view mgl32.Mat4) {
+	var model mgl32.Mat4
+	if t.Billboard {
+		right := mgl32.Vec3{view[0], view[4], view[8]}
+		up := mgl32.Vec3{view[1], view[5], view[9]}
+		origin := t.position.Sub(right.Mul(t.worldW / 2)).Sub(up.Mul(t.worldH / 2))
+		model = mgl32.Mat4{
+			right.X(), right.Y(), right.Z(), 0,
+			up.X(), up.Y(), up.Z(), 0,
+			0, 0, 1, 0,
+			origin.X(), origin.Y(), origin.Z(), 1,
+		}
+	} else {
+		model = mgl32.Translate3D(t.position.X(), t.position.Y(), t.position.Z())
+	}
+
+	gl.UseProgram(t.program)
+	gl.UniformMatrix4fv(t.modelUniform, 1, false, &model[0])
+	gl.Uniform3f(t.colorUniform, t.Color.X(), t.Color.Y(), t.Color.Z())
+
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, t.texture)
+	gl.BindVertexArray(t.vao)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	gl.Disable(gl.BLEND)
+}
+
+// Delete frees the label's GL resources.
+func (t *TextLabel) Delete() {
+	gl.DeleteTextures(1, &t.texture)
+	gl.DeleteVertexArrays(1, &t.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &t.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(t.program)
+	trackDelete("program")
+}
+
+const textVertexShader = `
+#version 460 core
+layout (location = 0) in vec2 aPos;
+layout (location = 1) in vec2 aUV;
+
+uniform mat4 model;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+out vec2 vUV;
+
+void main() {
+	gl_Position = projection * view * model * vec4(aPos, 0.0, 1.0);
+	vUV = aUV;
+}
+` + "\x00"
+
+const textFragmentShader = `
+#version 460 core
+in vec2 vUV;
+out vec4 FragColor;
+
+uniform sampler2D tex;
+uniform vec3 tintColor;
+
+void main() {
+	vec4 texColor = texture(tex, vUV);
+	FragColor = vec4(texColor.rgb * tintColor, texColor.a);
+}
+` + "\x00"
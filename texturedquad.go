@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// loadTexture decodes a PNG or JPEG file (registered via the image/png
+// and image/jpeg side-effect imports above) and uploads it as a 2D GL
+// texture with mipmaps, repeat wrapping, and trilinear filtering - the
+// same settings TextLabel's glyph texture uses, minus the mipmaps it
+// doesn't need at text's fixed on-screen size.
+func loadTexture(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("open texture %q: %w", path, err)
+	}
+	defer f.Close()
+
+	src, _, err := image.Decode(f)
+	if err != nil {
+		return 0, fmt.Errorf("decode texture %q: %w", path, err)
+	}
+
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			rgba.Set(x, y, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	var texture uint32
+	gl.GenTextures(1, &texture)
+	trackCreate("texture")
+	gl.BindTexture(gl.TEXTURE_2D, texture)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.REPEAT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.REPEAT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR_MIPMAP_LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(w), int32(h), 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(rgba.Pix))
+	gl.GenerateMipmap(gl.TEXTURE_2D)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	return texture, nil
+}
+
+// TexturedQuad is a flat image - a floor plan, a blueprint, a reference
+// photo - drawn on a quad lying in the XZ plane, the same ground plane
+// Grid occupies. Size is the quad's world-space side length; the image
+// itself is stretched to fill it regardless of its own aspect ratio.
+type TexturedQuad struct {
+	texture  uint32
+	program  uint32
+	vao, vbo uint32
+
+	modelUniform int32
+
+	Position mgl32.Vec3
+	Size     float32
+}
+
+// NewTexturedQuad loads path as a texture and builds a quad of the given
+// world-space size, centered on position and lying flat in the XZ plane
+// (matching Grid's orientation) rather than facing the camera.
+func NewTexturedQuad(path string, position mgl32.Vec3, size float32) (*TexturedQuad, error) {
+	texture, err := loadTexture(path)
+	if err != nil {
+		return nil, err
+	}
+
+	half := size / 2
+	vertices := []float32{
+		-half, 0, -half, 0, 0,
+		half, 0, -half, 1, 0,
+		half, 0, half, 1, 1,
+		-half, 0, -half, 0, 0,
+		half, 0, half, 1, 1,
+		-half, 0, half, 0, 1,
+	}
+
+	program, err := newProgram(texturedQuadVertexShader, texturedQuadFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	gl.UseProgram(program)
+	gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("tex\x00")), 0)
+
+	vao, vbo := newInterleavedBuffer(vertices, 3, 2)
+
+	return &TexturedQuad{
+		texture:      texture,
+		program:      program,
+		vao:          vao,
+		vbo:          vbo,
+		modelUniform: gl.GetUniformLocation(program, gl.Str("model\x00")),
+		Position:     position,
+		Size:         size,
+	}, nil
+}
+
+// Draw renders the quad. view/projection come from the shared Matrices
+// UBO (see updateMatricesUBO).
+func (q *TexturedQuad) Draw() {
+	model := mgl32.Translate3D(q.Position.X(), q.Position.Y(), q.Position.Z())
+	gl.UseProgram(q.program)
+	gl.UniformMatrix4fv(q.modelUniform, 1, false, &model[0])
+
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, q.texture)
+	gl.BindVertexArray(q.vao)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+}
+
+// Delete frees the quad's GL resources.
+func (q *TexturedQuad) Delete() {
+	gl.DeleteTextures(1, &q.texture)
+	trackDelete("texture")
+	gl.DeleteVertexArrays(1, &q.vao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &q.vbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(q.program)
+	trackDelete("program")
+}
+
+const texturedQuadVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+layout (location = 1) in vec2 aUV;
+
+uniform mat4 model;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+out vec2 vUV;
+
+void main() {
+	gl_Position = projection * view * model * vec4(aPos, 1.0);
+	vUV = aUV;
+}
+` + "\x00"
+
+const texturedQuadFragmentShader = `
+#version 460 core
+in vec2 vUV;
+out vec4 FragColor;
+
+uniform sampler2D tex;
+
+void main() {
+	FragColor = texture(tex, vUV);
+}
+` + "\x00"
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// matricesUBOBinding is the uniform buffer binding point shared by every
+// shader that needs the camera's projection/view matrices. A custom
+// shader (e.g. one loaded via loadShaderFromFiles) picks them up by
+// declaring the same std140 block at this binding, instead of taking
+// them as individual uniforms:
+//
+//	layout (std140, binding = 0) uniform Matrices {
+//	    mat4 projection;
+//	    mat4 view;
+//	};
+//
+// model stays a per-object uniform, set directly by each Draw call.
+const matricesUBOBinding uint32 = 0
+
+// newMatricesUBO allocates the shared Matrices UBO, sized for two mat4s,
+// and binds it to matricesUBOBinding for the life of the program.
+func newMatricesUBO() uint32 {
+	var ubo uint32
+	gl.GenBuffers(1, &ubo)
+	trackCreate("buffer")
+	gl.BindBuffer(gl.UNIFORM_BUFFER, ubo)
+	gl.BufferData(gl.UNIFORM_BUFFER, 2*16*4, nil, gl.DYNAMIC_DRAW)
+	gl.BindBufferBase(gl.UNIFORM_BUFFER, matricesUBOBinding, ubo)
+	return ubo
+}
+
+// updateMatricesUBO uploads this frame's projection/view matrices, in
+// the same order as the shader's Matrices block, so every program bound
+// to matricesUBOBinding picks them up without a per-program uniform call.
+func updateMatricesUBO(ubo uint32, projection, view mgl32.Mat4) {
+	gl.BindBuffer(gl.UNIFORM_BUFFER, ubo)
+	gl.BufferSubData(gl.UNIFORM_BUFFER, 0, 16*4, gl.Ptr(&projection[0]))
+	gl.BufferSubData(gl.UNIFORM_BUFFER, 16*4, 16*4, gl.Ptr(&view[0]))
+}
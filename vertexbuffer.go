@@ -0,0 +1,36 @@
+package main
+
+import "github.com/go-gl/gl/v4.6-core/gl"
+
+// newInterleavedBuffer uploads vertices to a new VAO/VBO pair and wires
+// up one vertex attribute per entry in attribSizes, interleaved in the
+// order given (e.g. {3, 4} for the position+color layout Grid and Axes
+// share). It exists to pull the GenVertexArrays/GenBuffers/BufferData/
+// VertexAttribPointer boilerplate that NewGridFromConfig,
+// NewAxesWithThickness and NewWireCube each used to duplicate into one
+// place.
+func newInterleavedBuffer(vertices []float32, attribSizes ...int32) (vao, vbo uint32) {
+	var stride int32
+	for _, size := range attribSizes {
+		stride += size
+	}
+
+	gl.GenVertexArrays(1, &vao)
+	trackCreate("vao")
+	gl.GenBuffers(1, &vbo)
+	trackCreate("buffer")
+
+	gl.BindVertexArray(vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, safeGLPtr(vertices), gl.STATIC_DRAW)
+
+	var offset int32
+	for i, size := range attribSizes {
+		gl.VertexAttribPointerWithOffset(uint32(i), size, gl.FLOAT, false, stride*4, uintptr(offset*4))
+		gl.EnableVertexAttribArray(uint32(i))
+		offset += size
+	}
+
+	gl.BindVertexArray(0)
+	return vao, vbo
+}
@@ -0,0 +1,81 @@
+package main
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// ViewportLayout selects how many panes the scene is split across.
+type ViewportLayout int
+
+const (
+	LayoutSingle ViewportLayout = iota
+	LayoutQuad
+)
+
+// Next cycles to the next layout, wrapping back to LayoutSingle.
+func (l ViewportLayout) Next() ViewportLayout {
+	return (l + 1) % 2
+}
+
+func (l ViewportLayout) String() string {
+	switch l {
+	case LayoutQuad:
+		return "quad"
+	default:
+		return "single"
+	}
+}
+
+// Viewport is one pane of a split-screen layout: a pixel rect (origin
+// bottom-left, matching gl.Viewport/gl.Scissor) and the camera it's
+// rendered from.
+type Viewport struct {
+	X, Y, Width, Height int32
+	Camera              *Camera
+}
+
+// Contains reports whether a window-space point (origin top-left, as
+// reported by GLFW cursor callbacks) falls inside v, given the window's
+// logical height.
+func (v Viewport) Contains(x, y float64, windowHeight int) bool {
+	// Flip y from GLFW's top-left origin to gl.Viewport's bottom-left one.
+	flippedY := float64(windowHeight) - y
+	return x >= float64(v.X) && x < float64(v.X+v.Width) &&
+		flippedY >= float64(v.Y) && flippedY < float64(v.Y+v.Height)
+}
+
+// quadViewports lays out four equal panes over a fbWidth x fbHeight
+// framebuffer: perspective top-left, top/front/side ortho filling the
+// rest, the layout modeling tools use for alignment work.
+func quadViewports(persp, top, front, side *Camera, fbWidth, fbHeight int) []Viewport {
+	halfW := int32(fbWidth / 2)
+	halfH := int32(fbHeight / 2)
+	return []Viewport{
+		{X: 0, Y: halfH, Width: halfW, Height: int32(fbHeight) - halfH, Camera: persp},
+		{X: halfW, Y: halfH, Width: int32(fbWidth) - halfW, Height: int32(fbHeight) - halfH, Camera: top},
+		{X: 0, Y: 0, Width: halfW, Height: halfH, Camera: front},
+		{X: halfW, Y: 0, Width: int32(fbWidth) - halfW, Height: halfH, Camera: side},
+	}
+}
+
+// viewportAt returns the pane containing the window-space point (x, y),
+// or nil if it falls outside all of them (shouldn't happen for a proper
+// tiling, but callers fall back to the default camera rather than crash).
+func viewportAt(viewports []Viewport, x, y float64, windowHeight int) *Viewport {
+	for i := range viewports {
+		if viewports[i].Contains(x, y, windowHeight) {
+			return &viewports[i]
+		}
+	}
+	return nil
+}
+
+// NewOrthoCamera builds a fixed-orientation orthographic camera looking
+// along the given yaw/pitch, for use as one of quadViewports' top/
+// front/side panes. distance sets OrthoHalfHeight so the scene starts at
+// a sensible scale instead of an arbitrary default.
+func NewOrthoCamera(position mgl32.Vec3, yaw, pitch, distance float32) *Camera {
+	c := NewCamera(position, yaw, pitch, 45)
+	c.Orthographic = true
+	c.OrthoHalfHeight = distance
+	c.TargetOrthoHalfHeight = distance
+	return c
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// outlineScale is how much larger the halo cube is drawn behind a
+// selected WireCube, in object-local units.
+const outlineScale = 1.08
+
+// WireCube is a simple axis-aligned cube, drawn either as 12 wireframe
+// edges or, when Solid is set, as a lit filled cube with per-face
+// normals and back-face culling. It's a placeholder scene object for
+// experimenting with per-object rendering (selection, outlines,
+// animation, ...) ahead of a real mesh pipeline.
+type WireCube struct {
+	edges *IndexedMesh
+
+	solidProgram       uint32
+	solidVao, solidVbo uint32
+	solidVertexCount   int32
+	solidModelUniform  int32
+	solidColorUniform  int32
+
+	Position mgl32.Vec3
+	Size     float32
+	Color    mgl32.Vec3
+
+	// Rotation is the cube's orientation, in degrees around X, Y, then
+	// Z; see eulerRotation. Zero (the default) leaves the cube
+	// axis-aligned, matching every cube built before this field existed.
+	Rotation   mgl32.Vec3
+	Selected   bool
+	Solid      bool
+	SolidColor mgl32.Vec3
+
+	// SpinAxis/SpinAngle drive an optional animated spin on top of the
+	// static Rotation above, advanced by updateSpin (see spin.go).
+	// SpinAxis defaults to +Y in NewWireCube so mgl32.HomogRotate3D
+	// always has a well-defined axis to normalize, even before any spin
+	// is configured; with SpinAngle at its zero value the extra rotation
+	// is the identity regardless of axis, so this is inert until
+	// -spin-axis/-spin-rate enable it.
+	SpinAxis  mgl32.Vec3
+	SpinAngle float32
+}
+
+// cubeCorners is the cube's 8 corners, each touched by 3 edges below;
+// an indexed mesh (see NewIndexedMesh) draws every edge from this one
+// shared copy of each corner instead of the 24-vertex duplicate-per-edge
+// layout this replaced.
+var cubeCorners = func() []float32 {
+	const h = 0.5
+	corners := [8][3]float32{
+		{-h, -h, -h}, {h, -h, -h}, {h, h, -h}, {-h, h, -h},
+		{-h, -h, h}, {h, -h, h}, {h, h, h}, {-h, h, h},
+	}
+	var verts []float32
+	for _, c := range corners {
+		verts = append(verts, c[0], c[1], c[2])
+	}
+	return verts
+}()
+
+// cubeEdgeIndices pairs up cubeCorners into the cube's 12 edges.
+var cubeEdgeIndices = []uint32{
+	0, 1, 1, 2, 2, 3, 3, 0,
+	4, 5, 5, 6, 6, 7, 7, 4,
+	0, 4, 1, 5, 2, 6, 3, 7,
+}
+
+// cubeFaceVerts is the solid cube as 6 faces of 2 triangles each (36
+// vertices, position+normal), flat-shaded with one normal per face
+// rather than sharing corners, so edges read crisply when lit.
+var cubeFaceVerts = func() []float32 {
+	const h = 0.5
+	corners := [8][3]float32{
+		{-h, -h, -h}, {h, -h, -h}, {h, h, -h}, {-h, h, -h},
+		{-h, -h, h}, {h, -h, h}, {h, h, h}, {-h, h, h},
+	}
+	type face struct {
+		corners [4]int
+		normal  [3]float32
+	}
+	faces := [6]face{
+		{[4]int{4, 5, 6, 7}, [3]float32{0, 0, 1}},
+		{[4]int{0, 3, 2, 1}, [3]float32{0, 0, -1}},
+		{[4]int{1, 2, 6, 5}, [3]float32{1, 0, 0}},
+		{[4]int{0, 4, 7, 3}, [3]float32{-1, 0, 0}},
+		{[4]int{3, 7, 6, 2}, [3]float32{0, 1, 0}},
+		{[4]int{0, 1, 5, 4}, [3]float32{0, -1, 0}},
+	}
+
+	var verts []float32
+	addVert := func(c [3]float32, n [3]float32) {
+		verts = append(verts, c[0], c[1], c[2], n[0], n[1], n[2])
+	}
+	for _, f := range faces {
+		a, b, c, d := corners[f.corners[0]], corners[f.corners[1]], corners[f.corners[2]], corners[f.corners[3]]
+		addVert(a, f.normal)
+		addVert(b, f.normal)
+		addVert(c, f.normal)
+		addVert(a, f.normal)
+		addVert(c, f.normal)
+		addVert(d, f.normal)
+	}
+	return verts
+}()
+
+// NewWireCube creates a cube centered on position, drawn as a
+// wireframe by default; set Solid to draw it filled instead.
+func NewWireCube(position mgl32.Vec3, size float32, color mgl32.Vec3) *WireCube {
+	solidProgram, err := newProgram(meshVertexShader, solidCubeFragmentShader)
+	if err != nil {
+		panic(err)
+	}
+
+	edges := NewIndexedMesh(cubeCorners, cubeEdgeIndices, gl.LINES)
+	solidVao, solidVbo := newInterleavedBuffer(cubeFaceVerts, 3, 3)
+
+	return &WireCube{
+		edges:             edges,
+		solidProgram:      solidProgram,
+		solidVao:          solidVao,
+		solidVbo:          solidVbo,
+		solidVertexCount:  int32(len(cubeFaceVerts) / 6),
+		solidModelUniform: gl.GetUniformLocation(solidProgram, gl.Str("model\x00")),
+		solidColorUniform: gl.GetUniformLocation(solidProgram, gl.Str("baseColor\x00")),
+		Position:          position,
+		Size:              size,
+		Color:             color,
+		SolidColor:        color,
+		SpinAxis:          mgl32.Vec3{0, 1, 0},
+	}
+}
+
+// Draw renders the cube solid, with back-face culling enabled, if
+// Solid is set; otherwise as a wireframe, plus a larger halo cube
+// behind it in the outline color when Selected. view/projection come
+// from the shared Matrices UBO (see updateMatricesUBO).
+func (c *WireCube) Draw() {
+	if c.Solid {
+		gl.Enable(gl.CULL_FACE)
+		gl.UseProgram(c.solidProgram)
+		model := modelMatrix(c.Position, c.Size, c.Rotation).Mul4(c.spinMatrix())
+		gl.UniformMatrix4fv(c.solidModelUniform, 1, false, &model[0])
+		gl.Uniform3f(c.solidColorUniform, c.SolidColor.X(), c.SolidColor.Y(), c.SolidColor.Z())
+		gl.BindVertexArray(c.solidVao)
+		gl.DrawArrays(gl.TRIANGLES, 0, c.solidVertexCount)
+		gl.BindVertexArray(0)
+		gl.Disable(gl.CULL_FACE)
+		return
+	}
+
+	if c.Selected {
+		setLineWidth(3)
+		model := modelMatrix(c.Position, c.Size*outlineScale, c.Rotation).Mul4(c.spinMatrix())
+		c.edges.Draw(model, mgl32.Vec4{1, 0.8, 0.2, 1})
+	}
+
+	setLineWidth(1)
+	model := modelMatrix(c.Position, c.Size, c.Rotation).Mul4(c.spinMatrix())
+	c.edges.Draw(model, mgl32.Vec4{c.Color.X(), c.Color.Y(), c.Color.Z(), 1})
+}
+
+// spinMatrix returns the cube's animated spin as a rotation matrix
+// around SpinAxis by SpinAngle degrees (see updateSpin in spin.go),
+// using mgl32.HomogRotate3D for an arbitrary axis rather than
+// eulerRotation's fixed X/Y/Z order.
+func (c *WireCube) spinMatrix() mgl32.Mat4 {
+	return mgl32.HomogRotate3D(mgl32.DegToRad(c.SpinAngle), c.SpinAxis)
+}
+
+// modelMatrix composes a TRS matrix: translate to position, rotate by
+// rotation (degrees around X, Y, then Z - see eulerRotation), then scale
+// uniformly by size.
+func modelMatrix(position mgl32.Vec3, size float32, rotation mgl32.Vec3) mgl32.Mat4 {
+	return mgl32.Translate3D(position.X(), position.Y(), position.Z()).
+		Mul4(eulerRotation(rotation)).
+		Mul4(mgl32.Scale3D(size, size, size))
+}
+
+// worldBounds returns the cube's axis-aligned world-space bounding box,
+// for frameAll. It ignores Rotation/spin - ok for a cube, where every
+// side is the same length, so a rotated cube's true bounds are never
+// more than a constant factor larger than this.
+func (c *WireCube) worldBounds() AABB {
+	half := c.Size / 2
+	offset := mgl32.Vec3{half, half, half}
+	return AABB{Min: c.Position.Sub(offset), Max: c.Position.Add(offset)}
+}
+
+// Delete frees the cube's GL resources, wireframe and solid alike.
+func (c *WireCube) Delete() {
+	c.edges.Delete()
+
+	gl.DeleteVertexArrays(1, &c.solidVao)
+	trackDelete("vao")
+	gl.DeleteBuffers(1, &c.solidVbo)
+	trackDelete("buffer")
+	gl.DeleteProgram(c.solidProgram)
+	trackDelete("program")
+}
+
+const outlineVertexShader = `
+#version 460 core
+layout (location = 0) in vec3 aPos;
+
+uniform mat4 model;
+layout (std140, binding = 0) uniform Matrices {
+	mat4 projection;
+	mat4 view;
+};
+
+void main() {
+	gl_Position = projection * view * model * vec4(aPos, 1.0);
+}
+` + "\x00"
+
+const outlineFragmentShader = `
+#version 460 core
+out vec4 FragColor;
+uniform vec4 color;
+
+void main() {
+	FragColor = color;
+}
+` + "\x00"
+
+// solidCubeFragmentShader shades a filled cube with the same fixed
+// directional light as meshFragmentShader, but takes its base color as
+// a uniform instead of hardcoding one, since WireCube.SolidColor is
+// meant to be distinct per cube.
+const solidCubeFragmentShader = `
+#version 460 core
+in vec3 vNormal;
+out vec4 FragColor;
+
+uniform vec3 baseColor;
+
+void main() {
+	vec3 lightDir = normalize(vec3(0.4, 0.8, 0.4));
+	float diffuse = max(dot(normalize(vNormal), lightDir), 0.0);
+	vec3 color = baseColor * (0.3 + 0.7 * diffuse);
+	FragColor = vec4(color, 1.0);
+}
+` + "\x00"
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"log"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// xrayModeActive is true while gl.DEPTH_TEST is disabled via the
+// KeyComma toggle in keyCallback, so lines and geometry show through
+// solid objects ("X-ray" style) instead of being occluded - useful for
+// inspecting overlapping geometry, or seeing the grid through the demo
+// cube.
+var xrayModeActive bool
+
+// toggleXRayMode flips gl.DEPTH_TEST and, while active, also enables
+// gl.BLEND with the standard alpha blend func: disabling depth test
+// alone only changes which surface draws last (whichever was drawn most
+// recently wins, fully opaque), not whether overlapping surfaces show
+// through each other - blending is what actually makes overlapping
+// geometry visible rather than just redundantly opaque. Both are
+// restored to the ambient state every other draw call already assumes
+// (depth test on, blend off - see beginLineBlend and
+// gridhighlight.go/hud.go/textlabel.go's own scoped blend use) on
+// toggle-back.
+func toggleXRayMode() {
+	xrayModeActive = !xrayModeActive
+	if xrayModeActive {
+		gl.Disable(gl.DEPTH_TEST)
+		gl.Enable(gl.BLEND)
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	} else {
+		gl.Enable(gl.DEPTH_TEST)
+		gl.Disable(gl.BLEND)
+	}
+	log.Printf("x-ray mode: %v", xrayModeActive)
+}